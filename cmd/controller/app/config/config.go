@@ -7,10 +7,12 @@ import (
 
 	componentbaseconfig "k8s.io/component-base/config"
 
+	craneclientset "github.com/gocrane/crane-scheduler/pkg/generated/clientset/versioned"
+	craneinformers "github.com/gocrane/crane-scheduler/pkg/generated/informers/externalversions"
 	policy "github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
 
 	annotatorconfig "github.com/gocrane/crane-scheduler/pkg/controller/annotator/config"
-	prom "github.com/gocrane/crane-scheduler/pkg/controller/prometheus"
+	"github.com/gocrane/crane-scheduler/pkg/controller/metrics"
 )
 
 // Config is the main context object for crane scheduler controller.
@@ -23,8 +25,12 @@ type Config struct {
 	KubeInformerFactory informers.SharedInformerFactory
 	// KubeClient is the general kube client.
 	KubeClient clientset.Interface
-	// PromClient is used for getting metric data from Prometheus.
-	PromClient prom.PromClient
+	// CraneClient is the clientset of crane scheduler APIs.
+	CraneClient craneclientset.Interface
+	// CraneInformerFactory gives access to informers of crane scheduler APIs.
+	CraneInformerFactory craneinformers.SharedInformerFactory
+	// MetricsProvider is used for getting metric data of nodes.
+	MetricsProvider metrics.MetricsProvider
 	// Policy is a collection of scheduler policies.
 	Policy *policy.DynamicSchedulerPolicy
 	// EventRecorder is the event sink
@@ -10,13 +10,20 @@ import (
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
+
+	"github.com/gocrane/crane-scheduler/pkg/controller/annotator"
 )
 
-// NewInformerFactory creates a SharedInformerFactory and initializes an event informer that returns specified events.
-func NewInformerFactory(cs clientset.Interface, resyncPeriod time.Duration) informers.SharedInformerFactory {
+// NewInformerFactory creates a SharedInformerFactory and initializes an event or pod informer that returns specified
+// objects, according to the binding source.
+func NewInformerFactory(cs clientset.Interface, resyncPeriod time.Duration, bindingSource string) informers.SharedInformerFactory {
 	informerFactory := informers.NewSharedInformerFactory(cs, resyncPeriod)
 
-	informerFactory.InformerFor(&v1.Event{}, newEventInformer)
+	if bindingSource == annotator.BindingSourceEvent {
+		informerFactory.InformerFor(&v1.Event{}, newEventInformer)
+	} else {
+		informerFactory.InformerFor(&v1.Pod{}, newPodInformer)
+	}
 
 	return informerFactory
 }
@@ -31,3 +38,14 @@ func newEventInformer(cs clientset.Interface, resyncPeriod time.Duration) cache.
 
 	return coreinformers.NewFilteredEventInformer(cs, metav1.NamespaceAll, resyncPeriod, nil, tweakListOptions)
 }
+
+// newPodInformer creates a shared index informer that returns only non-terminated pods.
+func newPodInformer(cs clientset.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	selector := fmt.Sprintf("status.phase!=%s,status.phase!=%s", v1.PodSucceeded, v1.PodFailed)
+
+	tweakListOptions := func(options *metav1.ListOptions) {
+		options.FieldSelector = selector
+	}
+
+	return coreinformers.NewFilteredPodInformer(cs, metav1.NamespaceAll, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, tweakListOptions)
+}
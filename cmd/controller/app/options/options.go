@@ -1,21 +1,28 @@
 package options
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/spf13/pflag"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	componentbaseconfig "k8s.io/component-base/config"
 	options "k8s.io/component-base/config/options"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 
 	controllerappconfig "github.com/gocrane/crane-scheduler/cmd/controller/app/config"
+	"github.com/gocrane/crane-scheduler/pkg/controller/annotator"
 	annotatorconfig "github.com/gocrane/crane-scheduler/pkg/controller/annotator/config"
+	"github.com/gocrane/crane-scheduler/pkg/controller/metrics"
 	"github.com/gocrane/crane-scheduler/pkg/controller/prometheus"
-	dynamicscheduler "github.com/gocrane/crane-scheduler/pkg/plugins/dynamic"
+	craneclientset "github.com/gocrane/crane-scheduler/pkg/generated/clientset/versioned"
+	craneinformers "github.com/gocrane/crane-scheduler/pkg/generated/informers/externalversions"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/loader"
 	utils "github.com/gocrane/crane-scheduler/pkg/utils"
 )
 
@@ -39,8 +46,13 @@ func NewOptions() (*Options, error) {
 	o := &Options{
 		AnnotatorConfiguration: &annotatorconfig.AnnotatorConfiguration{
 			BindingHeapSize:  1024,
+			BindingSource:    annotator.BindingSourcePod,
 			ConcurrentSyncs:  1,
 			PolicyConfigPath: "/etc/kubernetes/policy.yaml",
+			LoadStorage:      annotator.LoadStorageAnnotation,
+			MetricsProvider:  metrics.ProviderPrometheus,
+
+			PrometheusQueryTimeout: prometheus.DefaultPrometheusQueryTimeout,
 		},
 		LeaderElection: &componentbaseconfig.LeaderElectionConfiguration{
 			LeaderElect:       true,
@@ -64,8 +76,24 @@ func (o *Options) Flags(flag *pflag.FlagSet) error {
 	}
 
 	flag.StringVar(&o.PolicyConfigPath, "policy-config-path", o.PolicyConfigPath, "Path to annotator policy config")
-	flag.StringVar(&o.PrometheusAddr, "prometheus-address", o.PrometheusAddr, "The address of prometheus, from which we can pull metrics data.")
+	flag.StringVar(&o.PolicyName, "policy-name", o.PolicyName, "Name of DynamicSchedulerPolicy object, which takes precedence over policy-config-path if set")
+	flag.StringVar(&o.LoadStorage, "load-storage", o.LoadStorage, "Where to store the load of nodes, one of annotation, json-annotation and nodeload.")
+	flag.StringVar(&o.MetricsProvider, "metrics-provider", o.MetricsProvider, "Where to pull metrics data from, one of prometheus, metrics-server and static.")
+	flag.StringSliceVar(&o.PrometheusAddrs, "prometheus-address", o.PrometheusAddrs, "The addresses of prometheus, from which we can pull metrics data. The latter ones are used only when the former ones fail.")
+	flag.DurationVar(&o.PrometheusQueryTimeout, "prometheus-query-timeout", o.PrometheusQueryTimeout, "The timeout of each query against prometheus.")
+	flag.StringVar(&o.PrometheusTLSCAFile, "prometheus-tls-ca-file", o.PrometheusTLSCAFile, "Path to CA certificate to verify the certificate of prometheus.")
+	flag.StringVar(&o.PrometheusTLSCertFile, "prometheus-tls-cert-file", o.PrometheusTLSCertFile, "Path to client certificate to access prometheus.")
+	flag.StringVar(&o.PrometheusTLSKeyFile, "prometheus-tls-key-file", o.PrometheusTLSKeyFile, "Path to client key to access prometheus.")
+	flag.StringVar(&o.PrometheusTLSServerName, "prometheus-tls-server-name", o.PrometheusTLSServerName, "Server name to verify the certificate of prometheus.")
+	flag.BoolVar(&o.PrometheusTLSInsecureSkipVerify, "prometheus-tls-insecure-skip-verify", o.PrometheusTLSInsecureSkipVerify, "Skip the verification of prometheus certificate.")
+	flag.StringVar(&o.PrometheusBearerTokenFile, "prometheus-bearer-token-file", o.PrometheusBearerTokenFile, "Path to bearer token to access prometheus.")
+	flag.StringVar(&o.PrometheusBasicAuthUsername, "prometheus-basic-auth-username", o.PrometheusBasicAuthUsername, "Username of basic authentication to access prometheus.")
+	flag.StringVar(&o.PrometheusBasicAuthPasswordFile, "prometheus-basic-auth-password-file", o.PrometheusBasicAuthPasswordFile, "Path to password of basic authentication to access prometheus.")
+	flag.StringToStringVar(&o.PrometheusHeaders, "prometheus-headers", o.PrometheusHeaders, "Headers added to each request to prometheus, such as X-Scope-OrgID=tenant.")
+	flag.StringVar(&o.StaticMetricsPath, "static-metrics-path", o.StaticMetricsPath, "Path to static metrics file, used by static metrics provider for testing.")
+	flag.StringVar(&o.BindingSource, "binding-source", o.BindingSource, "Where to derive pod bindings from for hot value, one of pod and event.")
 	flag.Int32Var(&o.BindingHeapSize, "binding-heap-size", o.BindingHeapSize, "Max size of binding heap size, used to store hot value data.")
+	flag.MarkDeprecated("binding-heap-size", "bindings are counted per node in time buckets, whose memory is bounded.")
 	flag.Int32Var(&o.ConcurrentSyncs, "concurrent-syncs", o.ConcurrentSyncs, "The number of annotator controller workers that are allowed to sync concurrently.")
 	flag.StringVar(&o.kubeconfig, "kubeconfig", o.kubeconfig, "Path to kubeconfig file with authorization information")
 	flag.StringVar(&o.master, "master", o.master, "The address of the Kubernetes API server (overrides any value in kubeconfig)")
@@ -84,7 +112,46 @@ func (o *Options) ApplyTo(c *controllerappconfig.Config) error {
 
 // Validate validates the options and config before launching Annotator.
 func (o *Options) Validate() error {
-	return nil
+	var errs []error
+
+	if o.ConcurrentSyncs <= 0 {
+		errs = append(errs, fmt.Errorf("concurrent-syncs must be greater than 0"))
+	}
+
+	switch o.BindingSource {
+	case annotator.BindingSourcePod, annotator.BindingSourceEvent:
+	default:
+		errs = append(errs, fmt.Errorf("unknown binding source %q", o.BindingSource))
+	}
+
+	switch o.LoadStorage {
+	case annotator.LoadStorageAnnotation, annotator.LoadStorageJSONAnnotation, annotator.LoadStorageNodeLoad:
+	default:
+		errs = append(errs, fmt.Errorf("unknown load storage %q", o.LoadStorage))
+	}
+
+	switch o.MetricsProvider {
+	case metrics.ProviderPrometheus:
+		if err := o.prometheusClientConfig().Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	case metrics.ProviderMetricsServer:
+	case metrics.ProviderStatic:
+		if o.StaticMetricsPath == "" {
+			errs = append(errs, fmt.Errorf("static-metrics-path is required by static metrics provider"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown metrics provider %q", o.MetricsProvider))
+	}
+
+	// DynamicSchedulerPolicy object is validated once it is fetched from apiserver.
+	if o.PolicyName == "" {
+		if _, err := loader.LoadPolicyFromFile(o.PolicyConfigPath); err != nil {
+			errs = append(errs, fmt.Errorf("failed to load policy from %s: %v", o.PolicyConfigPath, err))
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
 }
 
 // Config returns an Annotator config object.
@@ -101,11 +168,6 @@ func (o *Options) Config() (*controllerappconfig.Config, error) {
 		return nil, err
 	}
 
-	c.Policy, err = dynamicscheduler.LoadPolicyFromFile(o.PolicyConfigPath)
-	if err != nil {
-		return nil, err
-	}
-
 	if o.kubeconfig == "" {
 		kubeconfig, err = rest.InClusterConfig()
 	} else {
@@ -121,16 +183,72 @@ func (o *Options) Config() (*controllerappconfig.Config, error) {
 		return nil, err
 	}
 
+	c.CraneClient, err = craneclientset.NewForConfig(rest.AddUserAgent(kubeconfig, ControllerUserAgent))
+	if err != nil {
+		return nil, err
+	}
+
+	if o.PolicyName != "" {
+		policyObj, err := c.CraneClient.SchedulerV1alpha1().DynamicSchedulerPolicies().Get(context.TODO(), o.PolicyName, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		c.Policy, err = loader.ConvertPolicyObject(policyObj)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		c.Policy, err = loader.LoadPolicyFromFile(o.PolicyConfigPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	c.LeaderElectionClient = clientset.NewForConfigOrDie(rest.AddUserAgent(kubeconfig, "leader-election"))
 
-	c.PromClient, err = prometheus.NewPromClient(o.PrometheusAddr)
+	c.MetricsProvider, err = o.newMetricsProvider(kubeconfig)
 	if err != nil {
 		return nil, err
 	}
 
-	c.KubeInformerFactory = NewInformerFactory(c.KubeClient, 0)
+	c.KubeInformerFactory = NewInformerFactory(c.KubeClient, 0, o.BindingSource)
+	c.CraneInformerFactory = craneinformers.NewSharedInformerFactory(c.CraneClient, 0)
 
 	c.HealthPort = o.healthPort
 
 	return c, nil
 }
+
+// newMetricsProvider creates the MetricsProvider specified by options.
+func (o *Options) newMetricsProvider(kubeconfig *rest.Config) (metrics.MetricsProvider, error) {
+	switch o.MetricsProvider {
+	case metrics.ProviderMetricsServer:
+		client, err := metricsclientset.NewForConfig(rest.AddUserAgent(kubeconfig, ControllerUserAgent))
+		if err != nil {
+			return nil, err
+		}
+		return metrics.NewMetricsServerProvider(client), nil
+	case metrics.ProviderStatic:
+		return metrics.NewStaticProvider(o.StaticMetricsPath), nil
+	default:
+		return prometheus.NewPromClient(o.prometheusClientConfig())
+	}
+}
+
+// prometheusClientConfig returns the config of prometheus client specified by options.
+func (o *Options) prometheusClientConfig() *prometheus.ClientConfig {
+	return &prometheus.ClientConfig{
+		Addresses:             o.PrometheusAddrs,
+		QueryTimeout:          o.PrometheusQueryTimeout,
+		TLSCAFile:             o.PrometheusTLSCAFile,
+		TLSCertFile:           o.PrometheusTLSCertFile,
+		TLSKeyFile:            o.PrometheusTLSKeyFile,
+		TLSServerName:         o.PrometheusTLSServerName,
+		TLSInsecureSkipVerify: o.PrometheusTLSInsecureSkipVerify,
+		BearerTokenFile:       o.PrometheusBearerTokenFile,
+		BasicAuthUsername:     o.PrometheusBasicAuthUsername,
+		BasicAuthPasswordFile: o.PrometheusBasicAuthPasswordFile,
+		Headers:               o.PrometheusHeaders,
+	}
+}
@@ -12,12 +12,16 @@ import (
 	"k8s.io/apiserver/pkg/server/healthz"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/component-base/metrics/legacyregistry"
 	"k8s.io/component-base/version"
 	"k8s.io/klog/v2"
 
 	"github.com/gocrane/crane-scheduler/cmd/controller/app/config"
 	"github.com/gocrane/crane-scheduler/cmd/controller/app/options"
 	"github.com/gocrane/crane-scheduler/pkg/controller/annotator"
+	craneclientset "github.com/gocrane/crane-scheduler/pkg/generated/clientset/versioned"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/loader"
 )
 
 // NewControllerCommand creates a *cobra.Command object with default parameters
@@ -61,22 +65,51 @@ func Run(cc *config.CompletedConfig, stopCh <-chan struct{}) error {
 	klog.Infof("Starting Controller version %+v", version.Get())
 
 	run := func(ctx context.Context) {
+		var craneClient craneclientset.Interface
+		if cc.AnnotatorConfig.PolicyName != "" {
+			craneClient = cc.CraneClient
+		}
+
+		loadStore, err := annotator.NewLoadStore(cc.AnnotatorConfig.LoadStorage, cc.KubeClient, cc.CraneClient)
+		if err != nil {
+			panic(err)
+		}
+
 		annotatorController := annotator.NewNodeAnnotator(
 			cc.KubeInformerFactory.Core().V1().Nodes(),
+			cc.KubeInformerFactory.Core().V1().Pods(),
 			cc.KubeInformerFactory.Core().V1().Events(),
+			cc.AnnotatorConfig.BindingSource,
 			cc.KubeClient,
-			cc.PromClient,
+			cc.MetricsProvider,
+			loadStore,
+			craneClient,
 			*cc.Policy,
-			cc.AnnotatorConfig.BindingHeapSize,
 		)
 
+		updatePolicy := func(p *policy.DynamicSchedulerPolicy) {
+			annotatorController.UpdatePolicy(*p)
+		}
+
+		if cc.AnnotatorConfig.PolicyName != "" {
+			loader.WatchPolicyObject(cc.CraneInformerFactory.Scheduler().V1alpha1().DynamicSchedulerPolicies(),
+				cc.AnnotatorConfig.PolicyName, updatePolicy)
+			cc.CraneInformerFactory.Start(stopCh)
+		} else {
+			policyWatcher := loader.NewPolicyWatcher(cc.AnnotatorConfig.PolicyConfigPath, cc.Policy, updatePolicy)
+			go policyWatcher.Run(stopCh)
+		}
+
 		cc.KubeInformerFactory.Start(stopCh)
 
 		panic(annotatorController.Run(int(cc.AnnotatorConfig.ConcurrentSyncs), stopCh))
 	}
 
+	annotator.RegisterMetrics()
+
 	healthMux := http.NewServeMux()
 	healthz.InstallHandler(healthMux, healthz.NamedCheck("crane-scheduler-controller", healthz.PingHealthz.Check))
+	healthMux.Handle("/metrics", legacyregistry.Handler())
 	go func() {
 		if err := http.ListenAndServe(fmt.Sprintf(":%s", cc.HealthPort), healthMux); err != nil {
 			klog.Fatal("failed to listen & server health server from port %s: %v", cc.HealthPort, err)
@@ -15,3 +15,17 @@ bash "${CODEGEN_PKG}"/generate-internal-groups.sh \
   github.com/gocrane/crane-scheduler/pkg/plugins/apis \
   "config:v1beta2,v1beta3" \
   --go-header-file "${SCRIPT_ROOT}"/hack/boilerplate.go.txt
+
+bash "${CODEGEN_PKG}"/generate-groups.sh \
+  "deepcopy" \
+  github.com/gocrane/crane-scheduler/pkg/generated \
+  github.com/gocrane/crane-scheduler/pkg/plugins/apis \
+  "nodeload:v1alpha1" \
+  --go-header-file "${SCRIPT_ROOT}"/hack/boilerplate.go.txt
+
+bash "${CODEGEN_PKG}"/generate-groups.sh \
+  "client,lister,informer" \
+  github.com/gocrane/crane-scheduler/pkg/generated \
+  github.com/gocrane/crane-scheduler/pkg/plugins/apis \
+  "policy:v1alpha1 nodeload:v1alpha1" \
+  --go-header-file "${SCRIPT_ROOT}"/hack/boilerplate.go.txt
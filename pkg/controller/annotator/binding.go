@@ -1,13 +1,19 @@
 package annotator
 
 import (
-	"container/heap"
 	"sync"
 	"time"
 
 	"k8s.io/klog/v2"
 )
 
+const (
+	// MaxBindingBuckets is the max number of time buckets kept for each node.
+	MaxBindingBuckets = 300
+	// DefaultBindingTimeRange is the time range of Bindings to be kept if no hot value policy exists.
+	DefaultBindingTimeRange = 5 * time.Minute
+)
+
 // Binding is a concise struction of pod binding records,
 // which consists of pod name, namespace name, node name and accurate timestamp.
 // Note that we only record temporary Binding imformation.
@@ -18,63 +24,117 @@ type Binding struct {
 	Timestamp int64
 }
 
-// BindingHeap is a Heap struction storing Binding imfromation.
-type BindingHeap []*Binding
+// bindingBucket is the count of Bindings in a time bucket.
+type bindingBucket struct {
+	// index is the timestamp divided by the bucket size.
+	index int64
+	count int32
+}
 
-func (b BindingHeap) Len() int {
-	return len(b)
+// nodeBindings counts the Bindings of a node in a ring of time buckets, so that its memory is
+// bounded however many pods are scheduled to the node.
+type nodeBindings struct {
+	buckets []bindingBucket
+	// latest is the index of the latest bucket with Bindings.
+	latest int64
 }
 
-func (b BindingHeap) Less(i, j int) bool {
-	return b[i].Timestamp < b[j].Timestamp
+func newNodeBindings(numBuckets int) *nodeBindings {
+	return &nodeBindings{buckets: make([]bindingBucket, numBuckets)}
 }
 
-func (b BindingHeap) Swap(i, j int) {
-	b[i], b[j] = b[j], b[i]
+func (nb *nodeBindings) add(index int64, count int32) {
+	// Bindings older than the ring are out of the time range already.
+	if index <= nb.latest-int64(len(nb.buckets)) {
+		return
+	}
+
+	bucket := &nb.buckets[index%int64(len(nb.buckets))]
+	if bucket.index != index {
+		// the bucket is reused once it falls out of the ring.
+		bucket.index, bucket.count = index, 0
+	}
+	bucket.count += count
+
+	if index > nb.latest {
+		nb.latest = index
+	}
 }
 
-func (b *BindingHeap) Pop() interface{} {
-	old := *b
-	n := len(old)
-	x := old[n-1]
-	*b = old[0 : n-1]
-	return x
+// count returns the number of Bindings in buckets whose index is greater than since, which
+// takes constant time as the number of buckets is bounded.
+func (nb *nodeBindings) count(since int64) int {
+	cnt := 0
+	for _, bucket := range nb.buckets {
+		if bucket.count > 0 && bucket.index > since {
+			cnt += int(bucket.count)
+		}
+	}
+
+	return cnt
 }
 
-func (b *BindingHeap) Push(x interface{}) {
-	*b = append(*b, x.(*Binding))
+// weightedCount sums the weight of Bindings in buckets whose index is greater than since,
+// where the weight of Bindings is given by the index of their bucket.
+func (nb *nodeBindings) weightedCount(since int64, weight func(index int64) float64) float64 {
+	var cnt float64
+	for _, bucket := range nb.buckets {
+		if bucket.count > 0 && bucket.index > since {
+			cnt += float64(bucket.count) * weight(bucket.index)
+		}
+	}
+
+	return cnt
 }
 
-// BindingRecords relizes an Binding heap, which limits heap size,
-// and recycles automatically, contributing to store latest and least imformation.
+// BindingRecords counts the recent Bindings of each node in time buckets, whose size is
+// chosen to cover the GC time range with at most MaxBindingBuckets buckets.
 type BindingRecords struct {
-	size        int32
-	bindings    *BindingHeap
+	nodes       map[string]*nodeBindings
 	gcTimeRange time.Duration
-	rw          sync.RWMutex
+	// bucketSeconds is the size of time buckets in seconds.
+	bucketSeconds int64
+	numBuckets    int
+	rw            sync.RWMutex
+}
+
+// NewBindingRecords returns an BindingRecords object, which keeps Bindings within tr.
+func NewBindingRecords(tr time.Duration) *BindingRecords {
+	br := &BindingRecords{
+		nodes: map[string]*nodeBindings{},
+	}
+	br.setTimeRange(tr)
+
+	return br
 }
 
-// NewBindingRecords returns an BindingRecords object.
-func NewBindingRecords(size int32, tr time.Duration) *BindingRecords {
-	bh := BindingHeap{}
-	heap.Init(&bh)
-	return &BindingRecords{
-		size:        size,
-		bindings:    &bh,
-		gcTimeRange: tr,
+func (br *BindingRecords) setTimeRange(tr time.Duration) {
+	br.gcTimeRange = tr
+	if tr <= 0 {
+		tr = DefaultBindingTimeRange
 	}
+
+	seconds := int64(tr.Seconds())
+	br.bucketSeconds = (seconds + MaxBindingBuckets - 1) / MaxBindingBuckets
+	if br.bucketSeconds < 1 {
+		br.bucketSeconds = 1
+	}
+	// an extra bucket covers the one partially out of the time range.
+	br.numBuckets = int((seconds+br.bucketSeconds-1)/br.bucketSeconds) + 1
 }
 
-// AddBinding add new Binding to BindingHeap.
+// AddBinding add new Binding to the records of its node.
 func (br *BindingRecords) AddBinding(b *Binding) {
 	br.rw.Lock()
 	defer br.rw.Unlock()
 
-	if br.bindings.Len() == int(br.size) {
-		heap.Pop(br.bindings)
+	nb, ok := br.nodes[b.Node]
+	if !ok {
+		nb = newNodeBindings(br.numBuckets)
+		br.nodes[b.Node] = nb
 	}
 
-	heap.Push(br.bindings, b)
+	nb.add(b.Timestamp/br.bucketSeconds, 1)
 }
 
 // GetLastNodeBindingCount caculates how many pods scheduled on specified node recently.
@@ -82,21 +142,72 @@ func (br *BindingRecords) GetLastNodeBindingCount(node string, timeRange time.Du
 	br.rw.RLock()
 	defer br.rw.RUnlock()
 
-	cnt, timeline := 0, time.Now().UTC().Unix()-int64(timeRange.Seconds())
+	nb, ok := br.nodes[node]
+	if !ok {
+		return 0
+	}
+
+	// a bucket is counted if it starts after the timeline.
+	timeline := time.Now().UTC().Unix() - int64(timeRange.Seconds())
+	cnt := nb.count(timeline / br.bucketSeconds)
+
+	klog.V(4).Infof("Node[%s] binding count is %d", node, cnt)
+
+	return cnt
+}
+
+// GetLastNodeBindingWeight sums the weight of Bindings scheduled on specified node recently,
+// where the weight of a Binding is given by its age, taken at the middle of its bucket.
+func (br *BindingRecords) GetLastNodeBindingWeight(node string, timeRange time.Duration, weight func(age time.Duration) float64) float64 {
+	br.rw.RLock()
+	defer br.rw.RUnlock()
+
+	nb, ok := br.nodes[node]
+	if !ok {
+		return 0
+	}
 
-	for _, binding := range *br.bindings {
-		if binding.Timestamp > timeline && binding.Node == node {
-			cnt++
+	now := time.Now().UTC().Unix()
+	timeline := now - int64(timeRange.Seconds())
+	value := nb.weightedCount(timeline/br.bucketSeconds, func(index int64) float64 {
+		age := float64(now) - (float64(index)+0.5)*float64(br.bucketSeconds)
+		if age < 0 {
+			age = 0
 		}
+		return weight(time.Duration(age * float64(time.Second)))
+	})
+
+	klog.V(4).Infof("Node[%s] binding weight is %f", node, value)
+
+	return value
+}
+
+// SetGCTimeRange updates the time range of Bindings to be kept, and moves the Bindings into
+// buckets of the new size if it changes.
+func (br *BindingRecords) SetGCTimeRange(tr time.Duration) {
+	br.rw.Lock()
+	defer br.rw.Unlock()
+
+	if br.gcTimeRange == tr {
+		return
 	}
 
-	klog.V(4).Infof("The total Binding count is %d, while node[%s] count is %d",
-		len(*br.bindings), node, cnt)
+	oldBucketSeconds := br.bucketSeconds
+	br.setTimeRange(tr)
 
-	return cnt
+	for node, old := range br.nodes {
+		nb := newNodeBindings(br.numBuckets)
+		for _, bucket := range old.buckets {
+			if bucket.count > 0 {
+				nb.add(bucket.index*oldBucketSeconds/br.bucketSeconds, bucket.count)
+			}
+		}
+		br.nodes[node] = nb
+	}
 }
 
-// BindingsGC recycles expired Bindings.
+// BindingsGC recycles the records of nodes without Bindings in the time range, such as
+// the deleted nodes.
 func (br *BindingRecords) BindingsGC() {
 	br.rw.Lock()
 	defer br.rw.Unlock()
@@ -107,17 +218,11 @@ func (br *BindingRecords) BindingsGC() {
 		return
 	}
 
-	timeline := time.Now().UTC().Unix() - int64(br.gcTimeRange.Seconds())
-	for br.bindings.Len() > 0 {
-		binding := heap.Pop(br.bindings).(*Binding)
-
-		klog.V(6).Infof("Try to recycle Binding(%v) with timeline %d", binding, timeline)
-
-		if binding.Timestamp > timeline {
-			heap.Push(br.bindings, binding)
-			return
+	timeline := (time.Now().UTC().Unix() - int64(br.gcTimeRange.Seconds())) / br.bucketSeconds
+	for node, nb := range br.nodes {
+		if nb.latest <= timeline {
+			klog.V(6).Infof("Recycle Bindings of node[%s] with timeline %d", node, timeline)
+			delete(br.nodes, node)
 		}
 	}
-
-	return
 }
@@ -0,0 +1,219 @@
+package annotator
+
+import (
+	"container/heap"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policy "github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+func TestBindingRecords(t *testing.T) {
+	now := time.Now().UTC().Unix()
+
+	br := NewBindingRecords(5 * time.Minute)
+	for _, b := range []*Binding{
+		{Node: "node1", Timestamp: now},
+		{Node: "node1", Timestamp: now - 30},
+		{Node: "node1", Timestamp: now - 120},
+		{Node: "node1", Timestamp: now - 600},
+		{Node: "node2", Timestamp: now - 10},
+	} {
+		br.AddBinding(b)
+	}
+
+	tests := []struct {
+		node      string
+		timeRange time.Duration
+		want      int
+	}{
+		{node: "node1", timeRange: time.Minute, want: 2},
+		{node: "node1", timeRange: 5 * time.Minute, want: 3},
+		{node: "node2", timeRange: time.Minute, want: 1},
+		{node: "node3", timeRange: time.Minute, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := br.GetLastNodeBindingCount(tt.node, tt.timeRange); got != tt.want {
+			t.Errorf("GetLastNodeBindingCount(%s, %v) = %d, want %d", tt.node, tt.timeRange, got, tt.want)
+		}
+	}
+
+	// a burst on a node does not evict the Bindings of others.
+	for i := 0; i < 10000; i++ {
+		br.AddBinding(&Binding{Node: "node1", Timestamp: now})
+	}
+	if got := br.GetLastNodeBindingCount("node2", time.Minute); got != 1 {
+		t.Errorf("GetLastNodeBindingCount(node2) = %d after burst on node1, want 1", got)
+	}
+
+	// Bindings are kept after the buckets are resized.
+	br.SetGCTimeRange(time.Hour)
+	if got := br.GetLastNodeBindingCount("node1", 5*time.Minute); got != 10003 {
+		t.Errorf("GetLastNodeBindingCount(node1) = %d after resizing, want 10003", got)
+	}
+
+	br.SetGCTimeRange(time.Minute)
+	br.AddBinding(&Binding{Node: "node3", Timestamp: now - 300})
+	br.BindingsGC()
+	if _, ok := br.nodes["node3"]; ok {
+		t.Errorf("Bindings of node3 are not recycled")
+	}
+	if got := br.GetLastNodeBindingCount("node2", time.Minute); got != 1 {
+		t.Errorf("GetLastNodeBindingCount(node2) = %d after GC, want 1", got)
+	}
+}
+
+// heapBindingRecords is the former implementation of BindingRecords, which scans a global heap
+// of Bindings, kept as the baseline of benchmarks.
+type heapBindingRecords struct {
+	size     int
+	bindings *bindingHeap
+	rw       sync.RWMutex
+}
+
+type bindingHeap []*Binding
+
+func (b bindingHeap) Len() int            { return len(b) }
+func (b bindingHeap) Less(i, j int) bool  { return b[i].Timestamp < b[j].Timestamp }
+func (b bindingHeap) Swap(i, j int)       { b[i], b[j] = b[j], b[i] }
+func (b *bindingHeap) Push(x interface{}) { *b = append(*b, x.(*Binding)) }
+func (b *bindingHeap) Pop() interface{} {
+	old := *b
+	n := len(old)
+	x := old[n-1]
+	*b = old[0 : n-1]
+	return x
+}
+
+func (br *heapBindingRecords) AddBinding(b *Binding) {
+	br.rw.Lock()
+	defer br.rw.Unlock()
+
+	if br.bindings.Len() == br.size {
+		heap.Pop(br.bindings)
+	}
+	heap.Push(br.bindings, b)
+}
+
+func (br *heapBindingRecords) GetLastNodeBindingCount(node string, timeRange time.Duration) int {
+	br.rw.RLock()
+	defer br.rw.RUnlock()
+
+	cnt, timeline := 0, time.Now().UTC().Unix()-int64(timeRange.Seconds())
+	for _, binding := range *br.bindings {
+		if binding.Timestamp > timeline && binding.Node == node {
+			cnt++
+		}
+	}
+
+	return cnt
+}
+
+type bindingRecorder interface {
+	AddBinding(b *Binding)
+	GetLastNodeBindingCount(node string, timeRange time.Duration) int
+}
+
+// BenchmarkGetNodeBindingCount measures a hot value sync of all nodes with two hot value
+// policies, after 1024 pods have been scheduled in the last 5 minutes.
+func BenchmarkGetNodeBindingCount(b *testing.B) {
+	for _, numNodes := range []int{100, 1000, 5000} {
+		recorders := map[string]func() bindingRecorder{
+			"heap": func() bindingRecorder {
+				return &heapBindingRecords{size: 1024, bindings: &bindingHeap{}}
+			},
+			"buckets": func() bindingRecorder {
+				return NewBindingRecords(5 * time.Minute)
+			},
+		}
+
+		for _, name := range []string{"heap", "buckets"} {
+			br := recorders[name]()
+			now := time.Now().UTC().Unix()
+			for i := 0; i < 1024; i++ {
+				br.AddBinding(&Binding{Node: fmt.Sprintf("node-%d", i%numNodes), Timestamp: now - int64(i%300)})
+			}
+
+			b.Run(fmt.Sprintf("%s/nodes=%d", name, numNodes), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					for n := 0; n < numNodes; n++ {
+						node := fmt.Sprintf("node-%d", n)
+						br.GetLastNodeBindingCount(node, time.Minute)
+						br.GetLastNodeBindingCount(node, 5*time.Minute)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkAddBinding(b *testing.B) {
+	recorders := map[string]bindingRecorder{
+		"heap":    &heapBindingRecords{size: 1024, bindings: &bindingHeap{}},
+		"buckets": NewBindingRecords(5 * time.Minute),
+	}
+
+	for _, name := range []string{"heap", "buckets"} {
+		br := recorders[name]
+		b.Run(name, func(b *testing.B) {
+			now := time.Now().UTC().Unix()
+			for i := 0; i < b.N; i++ {
+				br.AddBinding(&Binding{Node: fmt.Sprintf("node-%d", i%1000), Timestamp: now - int64(i%300)})
+			}
+		})
+	}
+}
+
+func TestHotValueModels(t *testing.T) {
+	now := time.Now().UTC().Unix()
+
+	br := NewBindingRecords(5 * time.Minute)
+	for i := 0; i < 4; i++ {
+		br.AddBinding(&Binding{Node: "node1", Timestamp: now})
+	}
+	br.AddBinding(&Binding{Node: "node1", Timestamp: now - 150})
+
+	fiveMinutes := metav1.Duration{Duration: 5 * time.Minute}
+	tests := []struct {
+		name   string
+		policy policy.HotValuePolicy
+		// the hot value is expected within [min, max], as bindings are aged by their buckets.
+		min, max float64
+	}{
+		{
+			name:   "step",
+			policy: policy.HotValuePolicy{TimeRange: fiveMinutes, Count: 10},
+			min:    0, max: 0,
+		},
+		{
+			name:   "step reaching count",
+			policy: policy.HotValuePolicy{TimeRange: fiveMinutes, Count: 2},
+			min:    2, max: 2,
+		},
+		{
+			name:   "linear",
+			policy: policy.HotValuePolicy{TimeRange: fiveMinutes, Count: 10, Model: policy.HotValueModelLinear},
+			// 4 recent bindings count 0.1 each, and the one in the middle of time range counts 0.05.
+			min: 0.44, max: 0.46,
+		},
+		{
+			name: "exponential decay",
+			policy: policy.HotValuePolicy{TimeRange: fiveMinutes, Count: 10, Model: policy.HotValueModelExponentialDecay,
+				HalfLife: metav1.Duration{Duration: 150 * time.Second}},
+			min: 0.44, max: 0.46,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getHotValue(br, "node1", tt.policy); got < tt.min || got > tt.max {
+				t.Errorf("getHotValue() = %f, want in [%f, %f]", got, tt.min, tt.max)
+			}
+		})
+	}
+}
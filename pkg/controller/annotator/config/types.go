@@ -1,14 +1,55 @@
 package config
 
+import (
+	"time"
+)
+
 // AnnotatorConfiguration holds configuration for a node annotator.
 type AnnotatorConfiguration struct {
 	// BindingHeapSize limits the size of Binding Heap, which stores the lastest
 	// pod scheduled imformation.
+	// Deprecated: Bindings are counted per node in time buckets, whose memory is bounded.
 	BindingHeapSize int32
+	// BindingSource specified where to derive pod bindings from, which is one of pod and
+	// event. pod watches the transition of spec.nodeName of pods, while event parses the
+	// messages of Scheduled events.
+	BindingSource string
 	// ConcurrentSyncs specified the number of annotator controller workers.
 	ConcurrentSyncs int32
 	// PolicyConfigPath specified the path of Scheduler Policy File.
 	PolicyConfigPath string
-	// PrometheusAddr is the address of Prometheus Service.
-	PrometheusAddr string
+	// PolicyName specified the name of DynamicSchedulerPolicy object, which takes
+	// precedence over PolicyConfigPath if set.
+	PolicyName string
+	// LoadStorage specified where to store the load of nodes, which is one of annotation,
+	// json-annotation and nodeload.
+	LoadStorage string
+	// MetricsProvider specified where to get metrics data from, which is one of
+	// prometheus, metrics-server and static.
+	MetricsProvider string
+	// PrometheusAddrs are the addresses of Prometheus Service, the latter ones are
+	// used only when the former ones fail.
+	PrometheusAddrs []string
+	// PrometheusQueryTimeout specified the timeout of each query against Prometheus.
+	PrometheusQueryTimeout time.Duration
+	// PrometheusTLSCAFile, PrometheusTLSCertFile and PrometheusTLSKeyFile specified
+	// the CA certificate, client certificate and key to talk to Prometheus over TLS.
+	PrometheusTLSCAFile   string
+	PrometheusTLSCertFile string
+	PrometheusTLSKeyFile  string
+	// PrometheusTLSServerName specified the server name to verify the certificate of Prometheus.
+	PrometheusTLSServerName string
+	// PrometheusTLSInsecureSkipVerify disables the verification of Prometheus certificate.
+	PrometheusTLSInsecureSkipVerify bool
+	// PrometheusBearerTokenFile specified the file of bearer token to access Prometheus.
+	PrometheusBearerTokenFile string
+	// PrometheusBasicAuthUsername and PrometheusBasicAuthPasswordFile specified the
+	// credentials of basic authentication to access Prometheus.
+	PrometheusBasicAuthUsername     string
+	PrometheusBasicAuthPasswordFile string
+	// PrometheusHeaders are added to each request to Prometheus, such as tenant header.
+	PrometheusHeaders map[string]string
+	// StaticMetricsPath specified the path of static metrics file, which is used
+	// by the static metrics provider.
+	StaticMetricsPath string
 }
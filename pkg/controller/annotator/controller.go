@@ -2,8 +2,11 @@ package annotator
 
 import (
 	"fmt"
+	"reflect"
+	"sync"
 	"time"
 
+	v1 "k8s.io/api/core/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	coreinformers "k8s.io/client-go/informers/core/v1"
@@ -12,9 +15,11 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
+	craneclientset "github.com/gocrane/crane-scheduler/pkg/generated/clientset/versioned"
 	policy "github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/helper"
 
-	prom "github.com/gocrane/crane-scheduler/pkg/controller/prometheus"
+	"github.com/gocrane/crane-scheduler/pkg/controller/metrics"
 )
 
 // Controller is Controller for node annotator.
@@ -23,60 +28,165 @@ type Controller struct {
 	nodeInformerSynced cache.InformerSynced
 	nodeLister         corelisters.NodeLister
 
+	// bindingSource is where pod bindings are derived from, which is one of pod and event.
+	bindingSource string
+
+	podInformer       coreinformers.PodInformer
+	podInformerSynced cache.InformerSynced
+	podLister         corelisters.PodLister
+
 	eventInformer       coreinformers.EventInformer
 	eventInformerSynced cache.InformerSynced
 	eventLister         corelisters.EventLister
 
-	kubeClient clientset.Interface
-	promClient prom.PromClient
+	kubeClient      clientset.Interface
+	metricsProvider metrics.MetricsProvider
+	loadStore       LoadStore
+	craneClient     craneclientset.Interface
+
+	policyLock sync.RWMutex
+	policy     policy.DynamicSchedulerPolicy
+	nodePools  *helper.NodePools
+	// syncPolicyUpdated notifies metric sync tickers that the sync policy has been changed.
+	syncPolicyUpdated chan struct{}
 
-	policy         policy.DynamicSchedulerPolicy
 	bindingRecords *BindingRecords
+	syncStatus     *syncStatusRecorder
 }
 
-// NewController returns a Node Annotator object.
+// NewController returns a Node Annotator object. loadStore persists the load of nodes, and
+// craneClient is used to write the status of DynamicSchedulerPolicy object, which is nil if
+// policy is loaded from file. Only the informer of bindingSource is used, which is one of
+// pod and event.
 func NewNodeAnnotator(
 	nodeInformer coreinformers.NodeInformer,
+	podInformer coreinformers.PodInformer,
 	eventInformer coreinformers.EventInformer,
+	bindingSource string,
 	kubeClient clientset.Interface,
-	promClient prom.PromClient,
+	metricsProvider metrics.MetricsProvider,
+	loadStore LoadStore,
+	craneClient craneclientset.Interface,
 	policy policy.DynamicSchedulerPolicy,
-	bingdingHeapSize int32,
 ) *Controller {
-	return &Controller{
-		nodeInformer:        nodeInformer,
-		nodeInformerSynced:  nodeInformer.Informer().HasSynced,
-		nodeLister:          nodeInformer.Lister(),
-		eventInformer:       eventInformer,
-		eventInformerSynced: eventInformer.Informer().HasSynced,
-		eventLister:         eventInformer.Lister(),
-		kubeClient:          kubeClient,
-		promClient:          promClient,
-		policy:              policy,
-		bindingRecords:      NewBindingRecords(bingdingHeapSize, getMaxHotVauleTimeRange(policy.Spec.HotValue)),
+	c := &Controller{
+		nodeInformer:       nodeInformer,
+		nodeInformerSynced: nodeInformer.Informer().HasSynced,
+		nodeLister:         nodeInformer.Lister(),
+		bindingSource:      bindingSource,
+		kubeClient:         kubeClient,
+		metricsProvider:    metricsProvider,
+		loadStore:          loadStore,
+		craneClient:        craneClient,
+		policy:             policy,
+		nodePools:          helper.NewNodePools(policy.Spec.NodePools),
+		syncPolicyUpdated:  make(chan struct{}, 1),
+		bindingRecords:     NewBindingRecords(getMaxHotVauleTimeRange(policy.Spec)),
+		syncStatus:         newSyncStatusRecorder(),
+	}
+
+	// informers are started by the factory once they are requested, so the unused one is
+	// left untouched.
+	if bindingSource == BindingSourceEvent {
+		c.eventInformer = eventInformer
+		c.eventInformerSynced = eventInformer.Informer().HasSynced
+		c.eventLister = eventInformer.Lister()
+	} else {
+		c.podInformer = podInformer
+		c.podInformerSynced = podInformer.Informer().HasSynced
+		c.podLister = podInformer.Lister()
 	}
+
+	return c
+}
+
+// UpdatePolicy replaces the scheduler policy in effect, and restarts metric sync tickers
+// if the sync policy has been changed.
+func (c *Controller) UpdatePolicy(p policy.DynamicSchedulerPolicy) {
+	c.policyLock.Lock()
+	syncPolicyChanged := !reflect.DeepEqual(c.policy.Spec.SyncPeriod, p.Spec.SyncPeriod) ||
+		!reflect.DeepEqual(c.policy.Spec.NodePools, p.Spec.NodePools)
+	c.policy = p
+	c.nodePools = helper.NewNodePools(p.Spec.NodePools)
+	c.policyLock.Unlock()
+
+	c.bindingRecords.SetGCTimeRange(getMaxHotVauleTimeRange(p.Spec))
+
+	if syncPolicyChanged {
+		select {
+		case c.syncPolicyUpdated <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (c *Controller) getPolicy() policy.DynamicSchedulerPolicy {
+	c.policyLock.RLock()
+	defer c.policyLock.RUnlock()
+
+	return c.policy
+}
+
+func (c *Controller) getNodePools() *helper.NodePools {
+	c.policyLock.RLock()
+	defer c.policyLock.RUnlock()
+
+	return c.nodePools
 }
 
 // Run runs node annotator.
 func (c *Controller) Run(worker int, stopCh <-chan struct{}) error {
 	defer utilruntime.HandleCrash()
 
-	eventController := newEventController(c)
-	c.eventInformer.Informer().AddEventHandler(eventController.handles())
+	cacheSyncs := []cache.InformerSynced{c.nodeInformerSynced}
+	if c.bindingSource == BindingSourceEvent {
+		cacheSyncs = append(cacheSyncs, c.eventInformerSynced)
+	} else {
+		cacheSyncs = append(cacheSyncs, c.podInformerSynced)
+	}
 
 	nodeController := newNodeController(c)
 
-	if !cache.WaitForCacheSync(stopCh, c.nodeInformerSynced, c.eventInformerSynced) {
+	if forgetter, ok := c.loadStore.(nodeForgetter); ok {
+		c.nodeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			DeleteFunc: func(obj interface{}) {
+				if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				if node, ok := obj.(*v1.Node); ok {
+					forgetter.ForgetNode(node.Name)
+				}
+			},
+		})
+	}
+
+	if !cache.WaitForCacheSync(stopCh, cacheSyncs...) {
 		return fmt.Errorf("failed to wait for cache sync for annotator")
 	}
 	klog.Info("Caches are synced for controller")
 
+	// binding records are rebuilt from the informer cache before hot values are published,
+	// and the handlers are added afterwards, which skip the objects counted by warm-up.
+	var eventController *eventController
+	if c.bindingSource == BindingSourceEvent {
+		eventController = newEventController(c)
+		eventController.warmUp()
+		c.eventInformer.Informer().AddEventHandler(eventController.handles())
+	} else {
+		podController := newPodController(c)
+		podController.warmUp()
+		c.podInformer.Informer().AddEventHandler(podController.handles())
+	}
+
 	for i := 0; i < worker; i++ {
 		go wait.Until(nodeController.Run, time.Second, stopCh)
-		go wait.Until(eventController.Run, time.Second, stopCh)
+		if eventController != nil {
+			go wait.Until(eventController.Run, time.Second, stopCh)
+		}
 	}
 
 	go wait.Until(c.bindingRecords.BindingsGC, time.Minute, stopCh)
+	go wait.Until(c.syncPolicyStatus, DefaultPolicyStatusSyncPeriod, stopCh)
 
 	nodeController.CreateMetricSyncTicker(stopCh)
 
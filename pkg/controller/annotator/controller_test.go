@@ -0,0 +1,98 @@
+package annotator
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	policy "github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/helper"
+)
+
+func newTestController(p policy.DynamicSchedulerPolicy, nodes ...*v1.Node) *Controller {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, node := range nodes {
+		indexer.Add(node)
+	}
+
+	return &Controller{
+		nodeLister:        corelisters.NewNodeLister(indexer),
+		policy:            p,
+		nodePools:         helper.NewNodePools(p.Spec.NodePools),
+		syncPolicyUpdated: make(chan struct{}, 1),
+		bindingRecords:    NewBindingRecords(getMaxHotVauleTimeRange(p.Spec)),
+		syncStatus:        newSyncStatusRecorder(),
+	}
+}
+
+func newSyncPolicy(name string, period time.Duration) policy.SyncPolicy {
+	return policy.SyncPolicy{Name: name, Period: metav1.Duration{Duration: period}}
+}
+
+// drainQueue returns the pending metrics of nodes in queue in the form of node/metric,
+// waiting at most timeout for the first node.
+func drainQueue(n *nodeController, timeout time.Duration) []string {
+	_ = wait.PollImmediate(10*time.Millisecond, timeout, func() (bool, error) {
+		return n.queue.Len() > 0, nil
+	})
+
+	var keys []string
+	for n.queue.Len() > 0 {
+		key, _ := n.queue.Get()
+		for _, metricName := range n.pending.Pop(key.(string)) {
+			keys = append(keys, key.(string)+"/"+metricName)
+		}
+		n.queue.Forget(key)
+		n.queue.Done(key)
+	}
+
+	return keys
+}
+
+func TestUpdatePolicyRestartsMetricSyncTickers(t *testing.T) {
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+	c := newTestController(policy.DynamicSchedulerPolicy{Spec: policy.PolicySpec{
+		SyncPeriod: []policy.SyncPolicy{newSyncPolicy("cpu_usage_avg_5m", time.Hour)},
+	}}, node)
+	n := newNodeController(c)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	n.CreateMetricSyncTicker(stopCh)
+
+	if keys := drainQueue(n, time.Second); len(keys) != 1 || keys[0] != "node1/cpu_usage_avg_5m" {
+		t.Fatalf("keys enqueued on start = %v, want [node1/cpu_usage_avg_5m]", keys)
+	}
+
+	// a policy change without sync policy changes keeps the tickers.
+	p := c.getPolicy()
+	p.Spec.HotValue = []policy.HotValuePolicy{{TimeRange: metav1.Duration{Duration: time.Minute}, Count: 5}}
+	c.UpdatePolicy(p)
+	if keys := drainQueue(n, 200*time.Millisecond); len(keys) != 0 {
+		t.Fatalf("keys enqueued after hot value policy changed = %v, want none", keys)
+	}
+
+	c.UpdatePolicy(policy.DynamicSchedulerPolicy{Spec: policy.PolicySpec{
+		SyncPeriod: []policy.SyncPolicy{newSyncPolicy("mem_usage_avg_5m", 50*time.Millisecond)},
+	}})
+
+	// the new ticker enqueues the new metric on start and then every period, while the
+	// ticker of removed metric is stopped.
+	synced := 0
+	for deadline := time.Now().Add(5 * time.Second); synced < 3 && time.Now().Before(deadline); {
+		for _, key := range drainQueue(n, time.Second) {
+			if key != "node1/mem_usage_avg_5m" {
+				t.Fatalf("unexpected key %q enqueued after sync policy changed", key)
+			}
+			synced++
+		}
+	}
+	if synced < 3 {
+		t.Errorf("mem_usage_avg_5m is synced %d times, want at least 3", synced)
+	}
+}
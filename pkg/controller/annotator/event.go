@@ -6,6 +6,7 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
@@ -13,7 +14,8 @@ import (
 
 type eventController struct {
 	*Controller
-	queue workqueue.RateLimitingInterface
+	queue    workqueue.RateLimitingInterface
+	warmedUp warmedUpObjects
 }
 
 func newEventController(c *Controller) *eventController {
@@ -22,9 +24,36 @@ func newEventController(c *Controller) *eventController {
 	return &eventController{
 		Controller: c,
 		queue:      workqueue.NewNamedRateLimitingQueue(eventRateLimiter, "EVENT_event_queue"),
+		warmedUp:   warmedUpObjects{},
 	}
 }
 
+// warmUp records the bindings of Scheduled events within the max time range of hot value.
+func (e *eventController) warmUp() {
+	events, err := e.eventLister.List(labels.Everything())
+	if err != nil {
+		klog.Warningf("failed to list events to warm up binding records: %v", err)
+		return
+	}
+
+	timeline := e.getWarmUpTimeline()
+	for _, event := range events {
+		if event.Type != v1.EventTypeNormal || event.Reason != "Scheduled" {
+			continue
+		}
+
+		binding, err := translateEventToBinding(event)
+		if err != nil || binding.Timestamp <= timeline {
+			continue
+		}
+
+		e.bindingRecords.AddBinding(binding)
+		e.warmedUp.add(event)
+	}
+
+	klog.Infof("Warmed up binding records with %d events", len(e.warmedUp))
+}
+
 func (e *eventController) Run() {
 	defer e.queue.ShutDown()
 	klog.Infof("Start to reconcile EVENT events")
@@ -52,6 +81,7 @@ func (e *eventController) handles() cache.ResourceEventHandlerFuncs {
 	return cache.ResourceEventHandlerFuncs{
 		AddFunc:    e.handleAddEvent,
 		UpdateFunc: e.handleUpdateEvent,
+		DeleteFunc: e.handleDeleteEvent,
 	}
 }
 
@@ -62,6 +92,10 @@ func (e *eventController) handleAddEvent(obj interface{}) {
 		return
 	}
 
+	if e.warmedUp.counted(event) {
+		return
+	}
+
 	e.enqueue(obj, cache.Added)
 }
 
@@ -79,6 +113,11 @@ func (e *eventController) handleUpdateEvent(old, new interface{}) {
 	e.enqueue(new, cache.Updated)
 }
 
+// handleDeleteEvent forgets the event counted by warm-up, in case it is deleted before replayed.
+func (e *eventController) handleDeleteEvent(obj interface{}) {
+	e.warmedUp.forget(obj)
+}
+
 func (e *eventController) enqueue(obj interface{}, action cache.DeltaType) {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
 	if err != nil {
@@ -110,6 +149,11 @@ func (e *eventController) reconcile(key string) error {
 		return err
 	}
 
+	// events replayed to the handler may occur long ago, out of the time range of hot value.
+	if binding.Timestamp <= e.getWarmUpTimeline() {
+		return nil
+	}
+
 	e.bindingRecords.AddBinding(binding)
 
 	return nil
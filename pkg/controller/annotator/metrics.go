@@ -0,0 +1,67 @@
+package annotator
+
+import (
+	"sync"
+
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+const (
+	// AnnotatorSubsystem is the subsystem name of node annotator metrics.
+	AnnotatorSubsystem = "crane_scheduler_annotator"
+
+	queryModeBatch = "batch"
+	queryModeNode  = "node"
+)
+
+var (
+	metricQueries = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      AnnotatorSubsystem,
+			Name:           "metric_queries_total",
+			Help:           "Number of queries to metrics provider, by metric, query mode and result.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"metric", "mode", "result"})
+
+	metricQueryDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem:      AnnotatorSubsystem,
+			Name:           "metric_query_duration_seconds",
+			Help:           "Latency of queries to metrics provider in seconds, by metric and query mode.",
+			Buckets:        metrics.ExponentialBuckets(0.001, 2, 15),
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"metric", "mode"})
+
+	metricSyncCycleNodes = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      AnnotatorSubsystem,
+			Name:           "metric_sync_cycle_nodes",
+			Help:           "Number of nodes covered by the last batch query of each metric.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"metric"})
+
+	annotatorMetrics = []metrics.Registerable{
+		metricQueries,
+		metricQueryDuration,
+		metricSyncCycleNodes,
+	}
+
+	registerMetrics sync.Once
+)
+
+// RegisterMetrics registers node annotator metrics.
+func RegisterMetrics() {
+	registerMetrics.Do(func() {
+		for _, metric := range annotatorMetrics {
+			legacyregistry.MustRegister(metric)
+		}
+	})
+}
+
+func queryResult(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
@@ -2,22 +2,25 @@ package annotator
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"strconv"
+	"sync"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
 	policy "github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/helper"
 
-	prom "github.com/gocrane/crane-scheduler/pkg/controller/prometheus"
-	utils "github.com/gocrane/crane-scheduler/pkg/utils"
+	"github.com/gocrane/crane-scheduler/pkg/controller/metrics"
 )
 
 const (
@@ -28,7 +31,48 @@ const (
 
 type nodeController struct {
 	*Controller
+	// queue is keyed by node name, so that the metrics due at the same time are written together.
 	queue workqueue.RateLimitingInterface
+	// samples caches the results of batch queries, which are consumed by node syncs.
+	samples *sampleCache
+	// pending records the metrics to be synced for each node in the queue.
+	pending *pendingMetrics
+}
+
+// pendingMetrics records the names of metrics to be synced for each node.
+type pendingMetrics struct {
+	lock    sync.Mutex
+	metrics map[string]sets.String
+}
+
+func newPendingMetrics() *pendingMetrics {
+	return &pendingMetrics{
+		metrics: map[string]sets.String{},
+	}
+}
+
+// Add adds the metrics to be synced for node.
+func (p *pendingMetrics) Add(nodeName string, metricNames ...string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	names, ok := p.metrics[nodeName]
+	if !ok {
+		names = sets.NewString()
+		p.metrics[nodeName] = names
+	}
+	names.Insert(metricNames...)
+}
+
+// Pop returns the metrics to be synced for node and removes them.
+func (p *pendingMetrics) Pop(nodeName string) []string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	names := p.metrics[nodeName]
+	delete(p.metrics, nodeName)
+
+	return names.List()
 }
 
 func newNodeController(c *Controller) *nodeController {
@@ -38,9 +82,18 @@ func newNodeController(c *Controller) *nodeController {
 	return &nodeController{
 		Controller: c,
 		queue:      workqueue.NewNamedRateLimitingQueue(nodeRateLimiter, "node_event_queue"),
+		samples:    newSampleCache(),
+		pending:    newPendingMetrics(),
 	}
 }
 
+// enqueue adds the metrics to be synced for node, which are written along with the
+// pending ones of node.
+func (n *nodeController) enqueue(nodeName string, metricNames ...string) {
+	n.pending.Add(nodeName, metricNames...)
+	n.queue.Add(nodeName)
+}
+
 func (n *nodeController) Run() {
 	defer n.queue.ShutDown()
 	klog.Infof("Start to reconcile node events")
@@ -69,123 +122,238 @@ func (n *nodeController) processNextWorkItem() bool {
 	return true
 }
 
-func (n *nodeController) syncNode(key string) (bool, error) {
+// syncNode queries the pending metrics of node, and writes them along with the hot value at once.
+// The metrics failed to sync are kept pending for the retry.
+func (n *nodeController) syncNode(nodeName string) (bool, error) {
 	startTime := time.Now()
 	defer func() {
-		klog.Infof("Finished syncing node event %q (%v)", key, time.Since(startTime))
+		klog.Infof("Finished syncing node event %q (%v)", nodeName, time.Since(startTime))
 	}()
 
-	nodeName, metricName, err := splitMetaKeyWithMetricName(key)
-	if err != nil {
-		return true, fmt.Errorf("invalid resource key: %s", key)
+	metricNames := n.pending.Pop(nodeName)
+	if len(metricNames) == 0 {
+		return true, nil
 	}
 
 	node, err := n.nodeLister.Get(nodeName)
 	if err != nil {
-		return true, fmt.Errorf("can not find node[%s]: %v", node, err)
+		return true, fmt.Errorf("can not find node[%s]: %v", nodeName, err)
 	}
 
-	err = annotateNodeLoad(n.promClient, n.kubeClient, node, metricName)
-	if err != nil {
-		return false, fmt.Errorf("can not annotate node[%s]: %v", node.Name, err)
+	p := n.getPolicy()
+	poolName, syncPolicies := getNodeSyncPolicies(p.Spec, n.getNodePools(), node)
+
+	samples, errs, failed := map[string]*metrics.Sample{}, []error{}, []string{}
+	for _, metricName := range metricNames {
+		// the metric may have been removed from sync policy since it was enqueued.
+		metric, ok := getSyncPolicy(syncPolicies, metricName)
+		if !ok {
+			continue
+		}
+
+		sample, err := queryNodeLoad(n.metricsProvider, n.samples, sampleKey(poolName, metricName), node, metric)
+		if err != nil {
+			n.syncStatus.Record(metricName, node.Name, err)
+			errs, failed = append(errs, err), append(failed, metricName)
+			continue
+		}
+		samples[metricName] = sample
 	}
 
-	err = annotateNodeHotValue(n.kubeClient, n.bindingRecords, node, n.policy)
-	if err != nil {
-		return false, err
+	if len(samples) > 0 {
+		// metrics and hot value are written at once to save requests to apiserver.
+		err := n.loadStore.UpdateLoad(node, samples, getNodeHotValue(n.bindingRecords, node, p.Spec, n.getNodePools()))
+		for metricName := range samples {
+			n.syncStatus.Record(metricName, node.Name, err)
+			if err != nil {
+				failed = append(failed, metricName)
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can not annotate node[%s]: %v", node.Name, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		n.pending.Add(nodeName, failed...)
+		return false, utilerrors.NewAggregate(errs)
 	}
 
 	return true, nil
 }
 
-func annotateNodeLoad(promClient prom.PromClient, kubeClient clientset.Interface, node *v1.Node, key string) error {
-	value, err := promClient.QueryByNodeIP(key, getNodeInternalIP(node))
-	if err == nil && len(value) > 0 {
-		return patchNodeAnnotation(kubeClient, node, key, value)
+// queryNodeLoad gets the sample of metric of node from the results of batch query of samplesKey,
+// or queries it from metrics provider if not found.
+func queryNodeLoad(metricsProvider metrics.MetricsProvider, samples *sampleCache, samplesKey string, node *v1.Node, metric policy.SyncPolicy) (*metrics.Sample, error) {
+	key := metric.Name
+
+	sample, ok := samples.Pop(samplesKey, node.Name)
+	if ok {
+		return sample, nil
 	}
-	value, err = promClient.QueryByNodeName(key, getNodeName(node))
-	if err == nil && len(value) > 0 {
-		return patchNodeAnnotation(kubeClient, node, key, value)
+
+	startTime := time.Now()
+	sample, err := metricsProvider.QueryByNode(metric, node)
+	metricQueryDuration.WithLabelValues(key, queryModeNode).Observe(time.Since(startTime).Seconds())
+	metricQueries.WithLabelValues(key, queryModeNode, queryResult(err)).Inc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data %s{%s}: %v", key, node.Name, err)
 	}
-	return fmt.Errorf("failed to get data %s{%s=%s}: %v", key, node.Name, value, err)
+
+	return sample, nil
 }
 
-func annotateNodeHotValue(kubeClient clientset.Interface, br *BindingRecords, node *v1.Node, policy policy.DynamicSchedulerPolicy) error {
-	var value int
+// getNodeHotValue returns the hot value of node according to the hot value policies applied on
+// it, which are those of its node pool if any.
+func getNodeHotValue(br *BindingRecords, node *v1.Node, spec policy.PolicySpec, nodePools *helper.NodePools) float64 {
+	var value float64
 
-	for _, p := range policy.Spec.HotValue {
-		value += br.GetLastNodeBindingCount(node.Name, p.TimeRange.Duration) / p.Count
+	for _, p := range helper.ApplyNodePool(spec, nodePools.Match(node)).HotValue {
+		value += getHotValue(br, node.Name, p)
 	}
 
-	return patchNodeAnnotation(kubeClient, node, HotValueKey, strconv.Itoa(value))
+	return value
 }
 
-func patchNodeAnnotation(kubeClient clientset.Interface, node *v1.Node, key, value string) error {
-	annotation := node.GetAnnotations()
-	if annotation == nil {
-		annotation = map[string]string{}
+// getHotValue returns the hot value of node according to the model of hotValuePolicy.
+func getHotValue(br *BindingRecords, nodeName string, hotValuePolicy policy.HotValuePolicy) float64 {
+	timeRange := hotValuePolicy.TimeRange.Duration
+
+	var weight float64
+	switch hotValuePolicy.Model {
+	case policy.HotValueModelLinear, policy.HotValueModelExponentialDecay:
+		weight = br.GetLastNodeBindingWeight(nodeName, timeRange, func(age time.Duration) float64 {
+			return helper.HotValueBindingWeight(hotValuePolicy, age)
+		})
+	default:
+		weight = float64(br.GetLastNodeBindingCount(nodeName, timeRange))
 	}
 
-	operator := "add"
-	_, exist := annotation[key]
-	if exist {
-		operator = "replace"
-	}
-
-	patchAnnotationTemplate :=
-		`[{
-		"op": "%s",
-		"path": "/metadata/annotations/%s",
-		"value": "%s"
-	}]`
+	return helper.HotValue(hotValuePolicy, weight)
+}
 
-	patchData := fmt.Sprintf(patchAnnotationTemplate, operator, key, value+","+utils.GetLocalTime())
+// patchNodeAnnotations writes annotations with a single merge patch.
+func patchNodeAnnotations(kubeClient clientset.Interface, node *v1.Node, annotations map[string]string) error {
+	patchData, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return err
+	}
 
-	_, err := kubeClient.CoreV1().Nodes().Patch(context.TODO(), node.Name, types.JSONPatchType, []byte(patchData), metav1.PatchOptions{})
+	_, err = kubeClient.CoreV1().Nodes().Patch(context.TODO(), node.Name, types.MergePatchType, patchData, metav1.PatchOptions{})
 	return err
 }
 
+// CreateMetricSyncTicker creates a ticker for each period in sync policies of spec and node pools,
+// which are recreated once the sync policies have been changed.
 func (n *nodeController) CreateMetricSyncTicker(stopCh <-chan struct{}) {
-
-	for _, p := range n.policy.Spec.SyncPeriod {
-		enqueueFunc := func(policy policy.SyncPolicy) {
-			nodes, err := n.nodeLister.List(labels.Everything())
-			if err != nil {
-				panic(fmt.Errorf("failed to list nodes: %v", err))
+	tickerStopCh := n.createMetricSyncTicker(n.getPolicy().Spec, stopCh)
+
+	go func() {
+		for {
+			select {
+			case <-n.syncPolicyUpdated:
+				klog.Infof("Sync policy has been changed, restart metric sync tickers")
+				close(tickerStopCh)
+				tickerStopCh = n.createMetricSyncTicker(n.getPolicy().Spec, stopCh)
+			case <-stopCh:
+				return
 			}
+		}
+	}()
+}
 
-			for _, node := range nodes {
-				n.queue.Add(handlingMetaKeyWithMetricName(node.Name, policy.Name))
-			}
+func (n *nodeController) createMetricSyncTicker(spec policy.PolicySpec, stopCh <-chan struct{}) chan struct{} {
+	tickerStopCh := make(chan struct{})
+	nodePools := helper.NewNodePools(spec.NodePools)
+
+	// sync policies of spec apply on the nodes of no node pool, or of node pools without their own.
+	syncPoliciesOfPool := map[string][]policy.SyncPolicy{"": spec.SyncPeriod}
+	for _, pool := range spec.NodePools {
+		if len(pool.SyncPeriod) > 0 {
+			syncPoliciesOfPool[pool.Name] = pool.SyncPeriod
 		}
+	}
+
+	for poolName, syncPolicies := range syncPoliciesOfPool {
+		// metrics with the same period are synced by the same ticker, so that they are
+		// written to each node with a single request.
+		syncPoliciesOfPeriod := map[time.Duration][]policy.SyncPolicy{}
+		for _, p := range syncPolicies {
+			syncPoliciesOfPeriod[p.Period.Duration] = append(syncPoliciesOfPeriod[p.Period.Duration], p)
+		}
+
+		for period, policies := range syncPoliciesOfPeriod {
+			enqueueFunc := func(poolName string, policies []policy.SyncPolicy) {
+				allNodes, err := n.nodeLister.List(labels.Everything())
+				if err != nil {
+					panic(fmt.Errorf("failed to list nodes: %v", err))
+				}
+
+				var nodes []*v1.Node
+				for _, node := range allNodes {
+					if name, _ := getNodeSyncPolicies(spec, nodePools, node); name == poolName {
+						nodes = append(nodes, node)
+					}
+				}
+
+				metricNames := make([]string, 0, len(policies))
+				for _, policy := range policies {
+					n.batchQuery(sampleKey(poolName, policy.Name), policy, nodes)
+					metricNames = append(metricNames, policy.Name)
+				}
 
-		enqueueFunc(p)
-
-		go func(policy policy.SyncPolicy) {
-			ticker := time.NewTicker(policy.Period.Duration)
-			defer ticker.Stop()
-			for {
-				select {
-				case <-ticker.C:
-					enqueueFunc(policy)
-				case <-stopCh:
-					return
+				for _, node := range nodes {
+					n.enqueue(node.Name, metricNames...)
 				}
 			}
-		}(p)
-	}
-}
 
-func getNodeInternalIP(node *v1.Node) string {
-	for _, addr := range node.Status.Addresses {
-		if addr.Type == v1.NodeInternalIP {
-			return addr.Address
+			enqueueFunc(poolName, policies)
+
+			go func(poolName string, period time.Duration, policies []policy.SyncPolicy) {
+				ticker := time.NewTicker(period)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ticker.C:
+						enqueueFunc(poolName, policies)
+					case <-tickerStopCh:
+						return
+					case <-stopCh:
+						return
+					}
+				}
+			}(poolName, period, policies)
 		}
 	}
 
-	return node.Name
+	return tickerStopCh
 }
 
-func getNodeName(node *v1.Node) string {
-	return node.Name
+// batchQuery queries the metric of nodes at once if it is supported by metrics provider, so
+// that node syncs in this cycle do not need to query one by one. The results are stored as samplesKey.
+func (n *nodeController) batchQuery(samplesKey string, metric policy.SyncPolicy, nodes []*v1.Node) {
+	batchProvider, ok := n.metricsProvider.(metrics.BatchMetricsProvider)
+	if !ok {
+		return
+	}
+
+	startTime := time.Now()
+	metricName := metric.Name
+
+	samples, err := batchProvider.QueryAllNodes(metric, nodes)
+	metricQueryDuration.WithLabelValues(metricName, queryModeBatch).Observe(time.Since(startTime).Seconds())
+	metricQueries.WithLabelValues(metricName, queryModeBatch, queryResult(err)).Inc()
+	if err != nil {
+		klog.Warningf("failed to query %s of all nodes, fall back to query by node: %v", metricName, err)
+		return
+	}
+
+	klog.V(4).Infof("Got %s of %d/%d nodes by batch query (%v)", metricName, len(samples), len(nodes), time.Since(startTime))
+	metricSyncCycleNodes.WithLabelValues(metricName).Set(float64(len(samples)))
+
+	n.samples.Set(samplesKey, samples)
 }
@@ -0,0 +1,126 @@
+package annotator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/gocrane/crane-scheduler/pkg/controller/metrics"
+	nodeloadv1alpha1 "github.com/gocrane/crane-scheduler/pkg/plugins/apis/nodeload/v1alpha1"
+	policy "github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+// fakeMetricsProvider returns 0.5 for all metrics except the failing ones.
+type fakeMetricsProvider struct {
+	failing map[string]bool
+}
+
+func (f *fakeMetricsProvider) QueryByNode(metric policy.SyncPolicy, node *v1.Node) (*metrics.Sample, error) {
+	if f.failing[metric.Name] {
+		return nil, fmt.Errorf("no data")
+	}
+
+	return &metrics.Sample{Value: 0.5, Timestamp: time.Now()}, nil
+}
+
+// processQueue syncs the nodes in queue until it is empty.
+func processQueue(n *nodeController) {
+	for n.queue.Len() > 0 {
+		n.processNextWorkItem()
+	}
+}
+
+func TestSyncNodeWritesMetricsOnce(t *testing.T) {
+	nodes := []*v1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node2"}},
+	}
+	kubeClient := fake.NewSimpleClientset(nodes[0], nodes[1])
+
+	c := newTestController(policy.DynamicSchedulerPolicy{Spec: policy.PolicySpec{
+		SyncPeriod: []policy.SyncPolicy{
+			newSyncPolicy("cpu_usage_avg_5m", time.Hour),
+			newSyncPolicy("mem_usage_avg_5m", time.Hour),
+			newSyncPolicy("cpu_usage_max_avg_1h", 2*time.Hour),
+		},
+		HotValue: []policy.HotValuePolicy{{TimeRange: metav1.Duration{Duration: 5 * time.Minute}, Count: 5}},
+	}}, nodes...)
+	provider := &fakeMetricsProvider{failing: map[string]bool{"cpu_usage_max_avg_1h": true}}
+	c.metricsProvider = provider
+	c.loadStore = NewJSONAnnotationLoadStore(kubeClient)
+	n := newNodeController(c)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	tickerStopCh := n.createMetricSyncTicker(c.getPolicy().Spec, stopCh)
+	defer close(tickerStopCh)
+
+	// patches returns the number of patches of each node since last call, and fails the
+	// test if nodes are read from apiserver.
+	patches := func() map[string]int {
+		counts := map[string]int{}
+		for _, action := range kubeClient.Actions() {
+			switch action.GetVerb() {
+			case "patch":
+				counts[action.(clienttesting.PatchAction).GetName()]++
+			case "get":
+				t.Errorf("node is read from apiserver when written")
+			}
+		}
+		kubeClient.ClearActions()
+		return counts
+	}
+
+	processQueue(n)
+
+	counts := patches()
+	for _, node := range nodes {
+		if counts[node.Name] != 1 {
+			t.Errorf("node[%s] is patched %d times in a sync cycle, want 1", node.Name, counts[node.Name])
+		}
+	}
+
+	// the failed metric is kept pending, and written alone once it succeeds.
+	if got := n.pending.metrics["node1"].List(); len(got) != 1 || got[0] != "cpu_usage_max_avg_1h" {
+		t.Fatalf("pending metrics of node1 = %v, want [cpu_usage_max_avg_1h]", got)
+	}
+	provider.failing = nil
+	for _, node := range nodes {
+		n.queue.Forget(node.Name)
+		n.queue.Add(node.Name)
+	}
+	processQueue(n)
+
+	counts = patches()
+	for _, node := range nodes {
+		if counts[node.Name] != 1 {
+			t.Errorf("node[%s] is patched %d times on retry, want 1", node.Name, counts[node.Name])
+		}
+	}
+
+	node, err := kubeClient.CoreV1().Nodes().Get(context.TODO(), "node1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get node: %v", err)
+	}
+
+	load := nodeloadv1alpha1.LoadAnnotation{}
+	if err := json.Unmarshal([]byte(node.Annotations[nodeloadv1alpha1.LoadAnnotationKey]), &load); err != nil {
+		t.Fatalf("failed to parse load annotation: %v", err)
+	}
+	// the metrics written in the former sync are kept.
+	for _, name := range []string{"cpu_usage_avg_5m", "mem_usage_avg_5m", "cpu_usage_max_avg_1h"} {
+		if got := load.Metrics[name].Value; got != 0.5 {
+			t.Errorf("%s of node1 = %v, want 0.5", name, got)
+		}
+	}
+	if load.HotValue == nil {
+		t.Errorf("hot value of node1 is not written")
+	}
+}
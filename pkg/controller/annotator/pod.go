@@ -0,0 +1,147 @@
+package annotator
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// BindingSourcePod derives pod bindings from the transition of spec.nodeName of pods.
+	BindingSourcePod = "pod"
+	// BindingSourceEvent derives pod bindings from the messages of Scheduled events.
+	BindingSourceEvent = "event"
+)
+
+type podController struct {
+	*Controller
+	warmedUp warmedUpObjects
+}
+
+func newPodController(c *Controller) *podController {
+	return &podController{Controller: c, warmedUp: warmedUpObjects{}}
+}
+
+// warmUp records the bindings of pods scheduled within the max time range of hot value.
+func (p *podController) warmUp() {
+	pods, err := p.podLister.List(labels.Everything())
+	if err != nil {
+		klog.Warningf("failed to list pods to warm up binding records: %v", err)
+		return
+	}
+
+	timeline := p.getWarmUpTimeline()
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+
+		binding, ok := translatePodToBinding(pod)
+		if !ok || binding.Timestamp <= timeline {
+			continue
+		}
+
+		p.bindingRecords.AddBinding(binding)
+		p.warmedUp.add(pod)
+	}
+
+	klog.Infof("Warmed up binding records with %d pods", len(p.warmedUp))
+}
+
+func (p *podController) handles() cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc:    p.handleAddPod,
+		UpdateFunc: p.handleUpdatePod,
+		DeleteFunc: p.handleDeletePod,
+	}
+}
+
+func (p *podController) handleAddPod(obj interface{}) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		return
+	}
+
+	// pods replayed to the handler are bound before the controller runs, only those not
+	// counted by warm-up, such as pods bound before the handler is added, are recorded.
+	if pod.Spec.NodeName == "" || p.warmedUp.counted(pod) {
+		return
+	}
+
+	p.addPodBinding(pod)
+}
+
+func (p *podController) handleUpdatePod(old, new interface{}) {
+	oldPod, ok := old.(*v1.Pod)
+	if !ok {
+		return
+	}
+	curPod, ok := new.(*v1.Pod)
+	if !ok {
+		return
+	}
+
+	if oldPod.Spec.NodeName != "" || curPod.Spec.NodeName == "" {
+		return
+	}
+
+	p.addPodBinding(curPod)
+}
+
+// handleDeletePod forgets the pod counted by warm-up, in case it is deleted before replayed.
+func (p *podController) handleDeletePod(obj interface{}) {
+	p.warmedUp.forget(obj)
+}
+
+func (p *podController) addPodBinding(pod *v1.Pod) {
+	binding, ok := translatePodToBinding(pod)
+	if !ok {
+		klog.V(5).Infof("Pod %s/%s is bound to node %s at unknown time, skipped", pod.Namespace, pod.Name, pod.Spec.NodeName)
+		return
+	}
+
+	// pods replayed to the handler may be bound long ago, which are out of the time range
+	// of hot value already.
+	if binding.Timestamp <= p.getWarmUpTimeline() {
+		klog.V(5).Infof("Pod %s/%s is bound to node %s before the time range of hot value, skipped", binding.Namespace, binding.PodName, binding.Node)
+		return
+	}
+
+	// pods replayed to the handler may be bound long ago, out of the time range of hot value.
+	if binding.Timestamp <= p.getWarmUpTimeline() {
+		klog.V(5).Infof("Pod %s/%s is bound to node %s before the time range of hot value, skipped", binding.Namespace, binding.PodName, binding.Node)
+		return
+	}
+
+	klog.V(5).Infof("Pod %s/%s is bound to node %s at %d", binding.Namespace, binding.PodName, binding.Node, binding.Timestamp)
+	p.bindingRecords.AddBinding(binding)
+}
+
+// translatePodToBinding returns the Binding of a bound pod, whose timestamp is the last
+// transition time of PodScheduled condition. Pods without the condition are not bound by
+// scheduler but created with spec.nodeName, so their creation time is used instead. It
+// returns false if neither is known.
+func translatePodToBinding(pod *v1.Pod) (*Binding, bool) {
+	var scheduledTime metav1.Time
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionTrue {
+			scheduledTime = condition.LastTransitionTime
+			break
+		}
+	}
+	if scheduledTime.IsZero() {
+		scheduledTime = pod.CreationTimestamp
+	}
+	if scheduledTime.IsZero() {
+		return nil, false
+	}
+
+	return &Binding{
+		Node:      pod.Spec.NodeName,
+		Namespace: pod.Namespace,
+		PodName:   pod.Name,
+		Timestamp: scheduledTime.Unix(),
+	}, true
+}
@@ -0,0 +1,207 @@
+package annotator
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestTranslatePodToBinding(t *testing.T) {
+	scheduledTime := time.Date(2022, 3, 1, 8, 0, 0, 0, time.UTC)
+	creationTime := scheduledTime.Add(-time.Minute)
+
+	withCreationTime := func(pod *v1.Pod) *v1.Pod {
+		pod.CreationTimestamp = metav1.NewTime(creationTime)
+		return pod
+	}
+	withoutCondition := func(pod *v1.Pod) *v1.Pod {
+		pod.Status.Conditions = nil
+		return pod
+	}
+
+	tests := []struct {
+		name          string
+		pod           *v1.Pod
+		wantOK        bool
+		wantTimestamp int64
+	}{
+		{
+			name:          "scheduled condition",
+			pod:           withCreationTime(newScheduledPod("pod", "node1", scheduledTime)),
+			wantOK:        true,
+			wantTimestamp: scheduledTime.Unix(),
+		},
+		{
+			name: "scheduled condition not true",
+			pod: func() *v1.Pod {
+				pod := withCreationTime(newScheduledPod("pod", "node1", scheduledTime))
+				pod.Status.Conditions[0].Status = v1.ConditionFalse
+				return pod
+			}(),
+			wantOK:        true,
+			wantTimestamp: creationTime.Unix(),
+		},
+		{
+			name:          "missing condition",
+			pod:           withCreationTime(withoutCondition(newScheduledPod("pod", "node1", scheduledTime))),
+			wantOK:        true,
+			wantTimestamp: creationTime.Unix(),
+		},
+		{
+			name:   "missing condition and creation time",
+			pod:    withoutCondition(newScheduledPod("pod", "node1", scheduledTime)),
+			wantOK: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			binding, ok := translatePodToBinding(test.pod)
+			if ok != test.wantOK {
+				t.Fatalf("translatePodToBinding() ok = %v, want %v", ok, test.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if binding.Timestamp != test.wantTimestamp {
+				t.Errorf("Timestamp = %d, want %d", binding.Timestamp, test.wantTimestamp)
+			}
+			if binding.Node != "node1" || binding.Namespace != "default" || binding.PodName != "pod" {
+				t.Errorf("binding = %+v, want pod default/pod on node1", binding)
+			}
+		})
+	}
+}
+
+func TestPodBindingHandlers(t *testing.T) {
+	now := time.Now()
+	timeRange := 5 * time.Minute
+
+	unbound := newScheduledPod("pod", "", time.Time{})
+	bound := newScheduledPod("pod", "node1", now)
+	unknownTime := newScheduledPod("pod", "node1", now)
+	unknownTime.Status.Conditions = nil
+	stale := newScheduledPod("pod", "node1", now.Add(-time.Hour))
+
+	tests := []struct {
+		name string
+		// warmedUp are the pods counted by warm-up.
+		warmedUp []*v1.Pod
+		add      *v1.Pod
+		// update is the old and new pod of an update, if any.
+		update    []*v1.Pod
+		wantCount int
+	}{
+		{
+			name:      "unbound to bound",
+			update:    []*v1.Pod{unbound, bound},
+			wantCount: 1,
+		},
+		{
+			name:      "bound to bound",
+			update:    []*v1.Pod{bound, bound},
+			wantCount: 0,
+		},
+		{
+			name:      "unbound to unbound",
+			update:    []*v1.Pod{unbound, unbound},
+			wantCount: 0,
+		},
+		{
+			name:      "already bound on add",
+			add:       bound,
+			wantCount: 1,
+		},
+		{
+			name:      "unbound on add",
+			add:       unbound,
+			wantCount: 0,
+		},
+		{
+			name:      "counted by warm-up on add",
+			warmedUp:  []*v1.Pod{bound},
+			add:       bound,
+			wantCount: 0,
+		},
+		{
+			name:      "other pod counted by warm-up on add",
+			warmedUp:  []*v1.Pod{newScheduledPod("other", "node2", now)},
+			add:       bound,
+			wantCount: 1,
+		},
+		{
+			name:      "bound before time range on add",
+			add:       stale,
+			wantCount: 0,
+		},
+		{
+			name:      "missing condition and creation time on add",
+			add:       unknownTime,
+			wantCount: 0,
+		},
+		{
+			name:      "missing condition and creation time on update",
+			update:    []*v1.Pod{unbound, unknownTime},
+			wantCount: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Controller{bindingRecords: NewBindingRecords(timeRange)}
+			pc := newPodController(c)
+			for _, pod := range test.warmedUp {
+				pc.warmedUp.add(pod)
+			}
+
+			if test.add != nil {
+				pc.handleAddPod(test.add)
+			}
+			if test.update != nil {
+				pc.handleUpdatePod(test.update[0], test.update[1])
+			}
+
+			if got := c.bindingRecords.GetLastNodeBindingCount("node1", timeRange); got != test.wantCount {
+				t.Errorf("GetLastNodeBindingCount() = %d, want %d", got, test.wantCount)
+			}
+			if test.wantCount == 0 && len(c.bindingRecords.nodes) != 0 {
+				t.Errorf("bindingRecords.nodes = %v, want no node recorded", c.bindingRecords.nodes)
+			}
+		})
+	}
+
+	// objects other than pods are ignored.
+	c := &Controller{bindingRecords: NewBindingRecords(timeRange)}
+	pc := newPodController(c)
+	pc.handleAddPod(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", UID: types.UID("node1")}})
+	pc.handleUpdatePod(unbound, &v1.Node{})
+	if got := c.bindingRecords.GetLastNodeBindingCount("node1", timeRange); got != 0 {
+		t.Errorf("GetLastNodeBindingCount() = %d for non-pod objects, want 0", got)
+	}
+}
+
+func TestPodBindingDeleteWarmedUp(t *testing.T) {
+	now := time.Now()
+	deleted := newScheduledPod("deleted", "node1", now)
+	tombstoned := newScheduledPod("tombstoned", "node1", now)
+	kept := newScheduledPod("kept", "node1", now)
+
+	pc := newPodController(&Controller{bindingRecords: NewBindingRecords(5 * time.Minute)})
+	for _, pod := range []*v1.Pod{deleted, tombstoned, kept} {
+		pc.warmedUp.add(pod)
+	}
+
+	pc.handleDeletePod(deleted)
+	pc.handleDeletePod(cache.DeletedFinalStateUnknown{Key: "default/tombstoned", Obj: tombstoned})
+
+	if len(pc.warmedUp) != 1 {
+		t.Fatalf("len(warmedUp) = %d after delete, want 1", len(pc.warmedUp))
+	}
+	if _, ok := pc.warmedUp[kept.UID]; !ok {
+		t.Errorf("warmedUp = %v, want pod kept", pc.warmedUp)
+	}
+}
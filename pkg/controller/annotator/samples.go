@@ -0,0 +1,42 @@
+package annotator
+
+import (
+	"sync"
+
+	"github.com/gocrane/crane-scheduler/pkg/controller/metrics"
+)
+
+// sampleCache stores the samples of batch queries, keyed by the metric name, which is prefixed
+// with the node pool if synced for a node pool, and node name.
+type sampleCache struct {
+	lock    sync.Mutex
+	samples map[string]map[string]*metrics.Sample
+}
+
+func newSampleCache() *sampleCache {
+	return &sampleCache{
+		samples: map[string]map[string]*metrics.Sample{},
+	}
+}
+
+// Set replaces the samples of key with the result of a new batch query.
+func (c *sampleCache) Set(key string, samples map[string]*metrics.Sample) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.samples[key] = samples
+}
+
+// Pop returns the sample of key on node and removes it, so that a retry of failed
+// node sync queries the latest data.
+func (c *sampleCache) Pop(key, nodeName string) (*metrics.Sample, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	sample, ok := c.samples[key][nodeName]
+	if ok {
+		delete(c.samples[key], nodeName)
+	}
+
+	return sample, ok
+}
@@ -0,0 +1,155 @@
+package annotator
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	policy "github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/v1alpha1"
+)
+
+const (
+	// DefaultPolicyStatusSyncPeriod is the interval of writing status of DynamicSchedulerPolicy.
+	DefaultPolicyStatusSyncPeriod = 30 * time.Second
+)
+
+type nodeSyncResult struct {
+	succeeded bool
+	message   string
+}
+
+// syncStatusRecorder records the last sync result of each metric on each node.
+type syncStatusRecorder struct {
+	lock         sync.Mutex
+	results      map[string]map[string]nodeSyncResult
+	lastSyncTime map[string]time.Time
+}
+
+func newSyncStatusRecorder() *syncStatusRecorder {
+	return &syncStatusRecorder{
+		results:      map[string]map[string]nodeSyncResult{},
+		lastSyncTime: map[string]time.Time{},
+	}
+}
+
+// Record records the sync result of metric on node.
+func (r *syncStatusRecorder) Record(metricName, nodeName string, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	nodeResults, ok := r.results[metricName]
+	if !ok {
+		nodeResults = map[string]nodeSyncResult{}
+		r.results[metricName] = nodeResults
+	}
+
+	result := nodeSyncResult{succeeded: err == nil}
+	if err != nil {
+		result.message = err.Error()
+	}
+
+	nodeResults[nodeName] = result
+	r.lastSyncTime[metricName] = time.Now()
+}
+
+// Status summarizes the sync results of metrics in sync policies of spec and node pools, ignoring the nodes which no longer exist.
+func (r *syncStatusRecorder) Status(spec policy.PolicySpec, nodes sets.String) policy.PolicyStatus {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	status, annotated := policy.PolicyStatus{}, sets.NewString()
+
+	for _, metricName := range getSyncedMetricNames(spec) {
+		metricStatus := policy.MetricSyncStatus{Name: metricName}
+
+		for nodeName, result := range r.results[metricName] {
+			if !nodes.Has(nodeName) {
+				delete(r.results[metricName], nodeName)
+				continue
+			}
+
+			if result.succeeded {
+				metricStatus.SucceededNodes++
+				annotated.Insert(nodeName)
+			} else {
+				metricStatus.FailedNodes++
+				metricStatus.Message = result.message
+			}
+		}
+
+		if t, ok := r.lastSyncTime[metricName]; ok {
+			metricStatus.LastSyncTime = metav1.NewTime(t)
+		}
+
+		switch {
+		case metricStatus.SucceededNodes == 0 && metricStatus.FailedNodes == 0:
+		case metricStatus.FailedNodes == 0:
+			metricStatus.Result = policy.MetricSyncSucceeded
+		case metricStatus.SucceededNodes == 0:
+			metricStatus.Result = policy.MetricSyncFailed
+		default:
+			metricStatus.Result = policy.MetricSyncPartiallyFailed
+		}
+
+		status.SyncStatus = append(status.SyncStatus, metricStatus)
+	}
+
+	status.AnnotatedNodes = int32(annotated.Len())
+
+	return status
+}
+
+// syncPolicyStatus writes the status of DynamicSchedulerPolicy object the controller consumes.
+func (c *Controller) syncPolicyStatus() {
+	if c.craneClient == nil {
+		return
+	}
+
+	p := c.getPolicy()
+
+	nodes, err := c.nodeLister.List(labels.Everything())
+	if err != nil {
+		klog.Warningf("failed to list nodes: %v", err)
+		return
+	}
+
+	nodeNames := sets.NewString()
+	for _, node := range nodes {
+		nodeNames.Insert(node.Name)
+	}
+
+	status := c.syncStatus.Status(p.Spec, nodeNames)
+	status.ObservedGeneration = p.Generation
+
+	policyClient := c.craneClient.SchedulerV1alpha1().DynamicSchedulerPolicies()
+
+	policyObj, err := policyClient.Get(context.TODO(), p.Name, metav1.GetOptions{})
+	if err != nil {
+		klog.Warningf("failed to get DynamicSchedulerPolicy %s: %v", p.Name, err)
+		return
+	}
+
+	newStatus := v1alpha1.PolicyStatus{}
+	if err := v1alpha1.Convert_policy_PolicyStatus_To_v1alpha1_PolicyStatus(&status, &newStatus, nil); err != nil {
+		klog.Warningf("failed to convert status of DynamicSchedulerPolicy %s: %v", p.Name, err)
+		return
+	}
+
+	if reflect.DeepEqual(policyObj.Status, newStatus) {
+		return
+	}
+
+	policyObj = policyObj.DeepCopy()
+	policyObj.Status = newStatus
+
+	if _, err := policyClient.UpdateStatus(context.TODO(), policyObj, metav1.UpdateOptions{}); err != nil {
+		klog.Warningf("failed to update status of DynamicSchedulerPolicy %s: %v", p.Name, err)
+	}
+}
@@ -0,0 +1,110 @@
+package annotator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	cranefake "github.com/gocrane/crane-scheduler/pkg/generated/clientset/versioned/fake"
+	policy "github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/v1alpha1"
+)
+
+func TestSyncStatus(t *testing.T) {
+	spec := policy.PolicySpec{
+		SyncPeriod: []policy.SyncPolicy{
+			newSyncPolicy("cpu_usage_avg_5m", time.Minute),
+			newSyncPolicy("mem_usage_avg_5m", time.Minute),
+			newSyncPolicy("cpu_usage_max_avg_1h", time.Minute),
+			newSyncPolicy("mem_usage_max_avg_1h", time.Minute),
+		},
+	}
+
+	r := newSyncStatusRecorder()
+	r.Record("cpu_usage_avg_5m", "node1", nil)
+	r.Record("cpu_usage_avg_5m", "node2", nil)
+	r.Record("mem_usage_avg_5m", "node1", nil)
+	r.Record("mem_usage_avg_5m", "node2", fmt.Errorf("no data"))
+	r.Record("cpu_usage_max_avg_1h", "node2", fmt.Errorf("no data"))
+	// results of the deleted node and the removed metric are ignored.
+	r.Record("cpu_usage_avg_5m", "node3", fmt.Errorf("no data"))
+	r.Record("removed", "node1", nil)
+
+	status := r.Status(spec, sets.NewString("node1", "node2"))
+
+	if status.AnnotatedNodes != 2 {
+		t.Errorf("AnnotatedNodes = %d, want 2", status.AnnotatedNodes)
+	}
+
+	want := []policy.MetricSyncStatus{
+		{Name: "cpu_usage_avg_5m", Result: policy.MetricSyncSucceeded, SucceededNodes: 2},
+		{Name: "mem_usage_avg_5m", Result: policy.MetricSyncPartiallyFailed, SucceededNodes: 1, FailedNodes: 1, Message: "no data"},
+		{Name: "cpu_usage_max_avg_1h", Result: policy.MetricSyncFailed, FailedNodes: 1, Message: "no data"},
+		{Name: "mem_usage_max_avg_1h"},
+	}
+	if len(status.SyncStatus) != len(want) {
+		t.Fatalf("got %d metric sync status, want %d", len(status.SyncStatus), len(want))
+	}
+
+	for i, got := range status.SyncStatus {
+		if got.LastSyncTime.IsZero() != (got.Name == "mem_usage_max_avg_1h") {
+			t.Errorf("LastSyncTime of %s = %v", got.Name, got.LastSyncTime)
+		}
+		got.LastSyncTime = metav1.Time{}
+		if got != want[i] {
+			t.Errorf("SyncStatus[%d] = %+v, want %+v", i, got, want[i])
+		}
+	}
+
+	if _, ok := r.results["cpu_usage_avg_5m"]["node3"]; ok {
+		t.Errorf("result of deleted node is not recycled")
+	}
+}
+
+func TestSyncPolicyStatus(t *testing.T) {
+	policyObj := &v1alpha1.DynamicSchedulerPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Generation: 3},
+	}
+	craneClient := cranefake.NewSimpleClientset(policyObj)
+
+	p := policy.DynamicSchedulerPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Generation: 3},
+		Spec: policy.PolicySpec{
+			SyncPeriod: []policy.SyncPolicy{newSyncPolicy("cpu_usage_avg_5m", time.Minute)},
+		},
+	}
+	c := newTestController(p, &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
+	c.craneClient = craneClient
+	c.syncStatus.Record("cpu_usage_avg_5m", "node1", nil)
+
+	c.syncPolicyStatus()
+
+	got, err := craneClient.SchedulerV1alpha1().DynamicSchedulerPolicies().Get(context.TODO(), "default", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get DynamicSchedulerPolicy: %v", err)
+	}
+
+	if got.Status.ObservedGeneration != 3 {
+		t.Errorf("ObservedGeneration = %d, want 3", got.Status.ObservedGeneration)
+	}
+	if got.Status.AnnotatedNodes != 1 {
+		t.Errorf("AnnotatedNodes = %d, want 1", got.Status.AnnotatedNodes)
+	}
+	if len(got.Status.SyncStatus) != 1 || got.Status.SyncStatus[0].Result != v1alpha1.MetricSyncSucceeded {
+		t.Errorf("SyncStatus = %+v, want cpu_usage_avg_5m succeeded", got.Status.SyncStatus)
+	}
+
+	// an unchanged status is not written again.
+	actions := len(craneClient.Actions())
+	c.syncPolicyStatus()
+	for _, action := range craneClient.Actions()[actions:] {
+		if action.GetVerb() == "update" {
+			t.Errorf("unchanged status is updated")
+		}
+	}
+}
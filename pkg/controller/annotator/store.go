@@ -0,0 +1,248 @@
+package annotator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientset "k8s.io/client-go/kubernetes"
+
+	"github.com/gocrane/crane-scheduler/pkg/controller/metrics"
+	craneclientset "github.com/gocrane/crane-scheduler/pkg/generated/clientset/versioned"
+	nodeloadv1alpha1 "github.com/gocrane/crane-scheduler/pkg/plugins/apis/nodeload/v1alpha1"
+	"github.com/gocrane/crane-scheduler/pkg/utils"
+)
+
+const (
+	// LoadStorageAnnotation stores node load in node annotations, one annotation per metric.
+	LoadStorageAnnotation = "annotation"
+	// LoadStorageJSONAnnotation stores node load in a single JSON encoded node annotation.
+	LoadStorageJSONAnnotation = "json-annotation"
+	// LoadStorageNodeLoad stores node load in NodeLoad objects.
+	LoadStorageNodeLoad = "nodeload"
+)
+
+// LoadStore persists the load of nodes, which is read by Dynamic plugin.
+type LoadStore interface {
+	// UpdateLoad writes the samples of metrics, along with the time they were collected, and
+	// the hot value of node at once.
+	UpdateLoad(node *v1.Node, samples map[string]*metrics.Sample, hotValue float64) error
+}
+
+// nodeForgetter is implemented by the LoadStore which keeps states of nodes, which are dropped
+// once the node is deleted.
+type nodeForgetter interface {
+	ForgetNode(nodeName string)
+}
+
+type annotationStore struct {
+	kubeClient clientset.Interface
+}
+
+// NewAnnotationLoadStore returns a LoadStore which writes node load into node annotations.
+func NewAnnotationLoadStore(kubeClient clientset.Interface) LoadStore {
+	return &annotationStore{kubeClient: kubeClient}
+}
+
+func (s *annotationStore) UpdateLoad(node *v1.Node, samples map[string]*metrics.Sample, hotValue float64) error {
+	annotations := map[string]string{
+		HotValueKey: formatAnnotationValue(hotValue, time.Now()),
+	}
+	for name, sample := range samples {
+		annotations[name] = formatAnnotationValue(sample.Value, sampleTimestamp(sample))
+	}
+
+	return patchNodeAnnotations(s.kubeClient, node, annotations)
+}
+
+type jsonAnnotationStore struct {
+	kubeClient clientset.Interface
+
+	lock sync.Mutex
+	// loads records the last load annotation written to each node, so that the metrics
+	// not synced this time are kept without reading the node from apiserver.
+	loads map[string]nodeloadv1alpha1.LoadAnnotation
+}
+
+// NewJSONAnnotationLoadStore returns a LoadStore which writes node load into a single node
+// annotation in JSON, so that all metrics and hot value are written by a single patch.
+func NewJSONAnnotationLoadStore(kubeClient clientset.Interface) LoadStore {
+	return &jsonAnnotationStore{
+		kubeClient: kubeClient,
+		loads:      map[string]nodeloadv1alpha1.LoadAnnotation{},
+	}
+}
+
+// UpdateLoad merges metrics into the load annotation last written to node, or the one
+// of node if written by the former leader. Updates of the same node are never concurrent,
+// as the node sync queue is keyed by node name.
+func (s *jsonAnnotationStore) UpdateLoad(node *v1.Node, samples map[string]*metrics.Sample, hotValue float64) error {
+	s.lock.Lock()
+	last, ok := s.loads[node.Name]
+	s.lock.Unlock()
+
+	if !ok {
+		if value, found := node.Annotations[nodeloadv1alpha1.LoadAnnotationKey]; found {
+			// a broken annotation is overwritten by the new one.
+			_ = json.Unmarshal([]byte(value), &last)
+		}
+	}
+
+	load := nodeloadv1alpha1.LoadAnnotation{
+		Metrics: make(map[string]nodeloadv1alpha1.MetricValue, len(last.Metrics)+len(samples)),
+	}
+	for name, value := range last.Metrics {
+		load.Metrics[name] = value
+	}
+	for name, sample := range samples {
+		load.Metrics[name] = newMetricValue(sample.Value, sampleTimestamp(sample))
+	}
+	hot := newMetricValue(hotValue, time.Now())
+	load.HotValue = &hot
+
+	value, err := json.Marshal(load)
+	if err != nil {
+		return err
+	}
+
+	patchData, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				nodeloadv1alpha1.LoadAnnotationKey: string(value),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.kubeClient.CoreV1().Nodes().Patch(context.TODO(), node.Name, types.MergePatchType, patchData, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	s.loads[node.Name] = load
+	s.lock.Unlock()
+
+	return nil
+}
+
+// ForgetNode drops the load annotation last written to the deleted node.
+func (s *jsonAnnotationStore) ForgetNode(nodeName string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.loads, nodeName)
+}
+
+type nodeLoadStore struct {
+	craneClient craneclientset.Interface
+}
+
+// NewNodeLoadStore returns a LoadStore which writes node load into NodeLoad objects, so that
+// watchers of nodes are not bothered by the frequent updates.
+func NewNodeLoadStore(craneClient craneclientset.Interface) LoadStore {
+	return &nodeLoadStore{craneClient: craneClient}
+}
+
+func (s *nodeLoadStore) UpdateLoad(node *v1.Node, samples map[string]*metrics.Sample, hotValue float64) error {
+	metricValues := map[string]nodeloadv1alpha1.MetricValue{}
+	for name, sample := range samples {
+		metricValues[name] = newMetricValue(sample.Value, sampleTimestamp(sample))
+	}
+	hot := newMetricValue(hotValue, time.Now())
+
+	patch := map[string]interface{}{
+		"metrics":  metricValues,
+		"hotValue": hot,
+	}
+
+	return s.patchNodeLoad(node, patch, &nodeloadv1alpha1.NodeLoad{Metrics: metricValues, HotValue: &hot})
+}
+
+// patchNodeLoad merges patch into the NodeLoad object of node, and creates nodeLoad instead
+// if the object is not found.
+func (s *nodeLoadStore) patchNodeLoad(node *v1.Node, patch map[string]interface{}, nodeLoad *nodeloadv1alpha1.NodeLoad) error {
+	patchData, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+
+	nodeLoadClient := s.craneClient.NodeLoadV1alpha1().NodeLoads()
+
+	_, err = nodeLoadClient.Patch(context.TODO(), node.Name, types.MergePatchType, patchData, metav1.PatchOptions{})
+	if !errors.IsNotFound(err) {
+		return err
+	}
+
+	nodeLoad.ObjectMeta = metav1.ObjectMeta{
+		Name: node.Name,
+		// NodeLoad object is garbage collected once the node is deleted. BlockOwnerDeletion is
+		// left unset, which would require the permission to update nodes/finalizers.
+		OwnerReferences: []metav1.OwnerReference{newNodeOwnerReference(node)},
+	}
+
+	_, err = nodeLoadClient.Create(context.TODO(), nodeLoad, metav1.CreateOptions{})
+	if errors.IsAlreadyExists(err) {
+		_, err = nodeLoadClient.Patch(context.TODO(), node.Name, types.MergePatchType, patchData, metav1.PatchOptions{})
+	}
+
+	return err
+}
+
+// NewLoadStore returns the LoadStore of storage, which is one of annotation, json-annotation
+// and nodeload.
+func NewLoadStore(storage string, kubeClient clientset.Interface, craneClient craneclientset.Interface) (LoadStore, error) {
+	switch storage {
+	case LoadStorageAnnotation:
+		return NewAnnotationLoadStore(kubeClient), nil
+	case LoadStorageJSONAnnotation:
+		return NewJSONAnnotationLoadStore(kubeClient), nil
+	case LoadStorageNodeLoad:
+		return NewNodeLoadStore(craneClient), nil
+	default:
+		return nil, fmt.Errorf("unknown load storage %q", storage)
+	}
+}
+
+func newNodeOwnerReference(node *v1.Node) metav1.OwnerReference {
+	gvk := v1.SchemeGroupVersion.WithKind("Node")
+	isController := true
+
+	return metav1.OwnerReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       node.Name,
+		UID:        node.UID,
+		Controller: &isController,
+	}
+}
+
+func newMetricValue(value float64, timestamp time.Time) nodeloadv1alpha1.MetricValue {
+	return nodeloadv1alpha1.MetricValue{
+		Value:     value,
+		Timestamp: metav1.NewTime(timestamp),
+	}
+}
+
+// sampleTimestamp returns the time sample was collected, or now if the provider does not
+// report it.
+func sampleTimestamp(sample *metrics.Sample) time.Time {
+	if sample.Timestamp.IsZero() {
+		return time.Now()
+	}
+
+	return sample.Timestamp
+}
+
+// formatAnnotationValue formats value in the form of "value,timestamp".
+func formatAnnotationValue(value float64, timestamp time.Time) string {
+	return strconv.FormatFloat(value, 'f', 5, 64) + "," + utils.FormatTimestamp(timestamp)
+}
@@ -0,0 +1,80 @@
+package annotator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/gocrane/crane-scheduler/pkg/controller/metrics"
+	cranefake "github.com/gocrane/crane-scheduler/pkg/generated/clientset/versioned/fake"
+	"github.com/gocrane/crane-scheduler/pkg/utils"
+)
+
+func TestNodeLoadStore(t *testing.T) {
+	craneClient := cranefake.NewSimpleClientset()
+	store := NewNodeLoadStore(craneClient)
+
+	collected := time.Now().Add(-time.Minute).Truncate(time.Second)
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", UID: "uid1"}}
+	samples := map[string]*metrics.Sample{"cpu_usage_avg_5m": {Value: 0.3, Timestamp: collected}}
+	if err := store.UpdateLoad(node, samples, 1); err != nil {
+		t.Fatalf("UpdateLoad() error = %v", err)
+	}
+
+	nodeLoad, err := craneClient.NodeLoadV1alpha1().NodeLoads().Get(context.TODO(), "node1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get NodeLoad: %v", err)
+	}
+
+	if got := nodeLoad.Metrics["cpu_usage_avg_5m"].Value; got != 0.3 {
+		t.Errorf("cpu_usage_avg_5m = %v, want 0.3", got)
+	}
+	// the time the sample was collected is kept, rather than the time it is written.
+	if got := nodeLoad.Metrics["cpu_usage_avg_5m"].Timestamp.Time; !got.Equal(collected) {
+		t.Errorf("timestamp of cpu_usage_avg_5m = %v, want %v", got, collected)
+	}
+
+	if len(nodeLoad.OwnerReferences) != 1 {
+		t.Fatalf("got %d owner references, want 1", len(nodeLoad.OwnerReferences))
+	}
+	ref := nodeLoad.OwnerReferences[0]
+	if ref.Kind != "Node" || ref.Name != "node1" || ref.UID != "uid1" || ref.Controller == nil || !*ref.Controller {
+		t.Errorf("owner reference = %+v, want controller reference to node1", ref)
+	}
+	// blocking owner deletion requires the permission to update nodes/finalizers.
+	if ref.BlockOwnerDeletion != nil {
+		t.Errorf("BlockOwnerDeletion = %v, want unset", *ref.BlockOwnerDeletion)
+	}
+}
+
+func TestAnnotationStoreWritesSampleTimestamp(t *testing.T) {
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+	kubeClient := fake.NewSimpleClientset(node)
+	store := NewAnnotationLoadStore(kubeClient)
+
+	collected := time.Now().Add(-time.Minute)
+	samples := map[string]*metrics.Sample{
+		"cpu_usage_avg_5m": {Value: 0.3, Timestamp: collected},
+		// samples without timestamp are written at the current time.
+		"mem_usage_avg_5m": {Value: 0.4},
+	}
+	if err := store.UpdateLoad(node, samples, 1); err != nil {
+		t.Fatalf("UpdateLoad() error = %v", err)
+	}
+
+	node, err := kubeClient.CoreV1().Nodes().Get(context.TODO(), "node1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get node: %v", err)
+	}
+
+	if got, want := node.Annotations["cpu_usage_avg_5m"], "0.30000,"+utils.FormatTimestamp(collected); got != want {
+		t.Errorf("cpu_usage_avg_5m = %q, want %q", got, want)
+	}
+	if got := node.Annotations["mem_usage_avg_5m"]; got == "0.40000,"+utils.FormatTimestamp(time.Time{}) {
+		t.Errorf("mem_usage_avg_5m = %q, want the current time", got)
+	}
+}
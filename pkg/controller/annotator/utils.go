@@ -1,39 +1,83 @@
 package annotator
 
 import (
-	"fmt"
-	"strings"
 	"time"
 
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
 	policy "github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/helper"
 )
 
-func splitMetaKeyWithMetricName(key string) (string, string, error) {
-	parts := strings.Split(key, "/")
+// getMaxHotVauleTimeRange returns the max time range of hot value policies of spec and its
+// node pools.
+func getMaxHotVauleTimeRange(spec policy.PolicySpec) time.Duration {
+	var max time.Duration
+
+	update := func(hotValues []policy.HotValuePolicy) {
+		for _, tr := range hotValues {
+			if max < tr.TimeRange.Duration {
+				max = tr.TimeRange.Duration
+			}
+		}
+	}
 
-	if len(parts) != 2 {
-		return "", "", fmt.Errorf("unexpected key format: %q", key)
+	update(spec.HotValue)
+	for _, pool := range spec.NodePools {
+		update(pool.HotValue)
 	}
 
-	return parts[0], parts[1], nil
+	return max
 }
 
-func handlingMetaKeyWithMetricName(nodeName, metricName string) string {
-	return nodeName + "/" + metricName
+func getSyncPolicy(syncPolicies []policy.SyncPolicy, metricName string) (policy.SyncPolicy, bool) {
+	for _, sp := range syncPolicies {
+		if sp.Name == metricName {
+			return sp, true
+		}
+	}
+
+	return policy.SyncPolicy{}, false
 }
 
-func getMaxHotVauleTimeRange(hotValues []policy.HotValuePolicy) time.Duration {
-	var max time.Duration
+// getNodeSyncPolicies returns the sync policies applied on node, and the name of node pool they
+// belong to, which is empty for the sync policies of spec.
+func getNodeSyncPolicies(spec policy.PolicySpec, nodePools *helper.NodePools, node *v1.Node) (string, []policy.SyncPolicy) {
+	if pool := nodePools.Match(node); pool != nil && len(pool.SyncPeriod) > 0 {
+		return pool.Name, pool.SyncPeriod
+	}
+
+	return "", spec.SyncPeriod
+}
 
-	if hotValues == nil {
-		return max
+// sampleKey is the key of batch query results of metric synced for node pool.
+func sampleKey(poolName, metricName string) string {
+	if poolName == "" {
+		return metricName
 	}
 
-	for _, tr := range hotValues {
-		if max < tr.TimeRange.Duration {
-			max = tr.TimeRange.Duration
+	return poolName + "/" + metricName
+}
+
+// getSyncedMetricNames returns the names of metrics synced for any node.
+func getSyncedMetricNames(spec policy.PolicySpec) []string {
+	names := sets.NewString()
+	var ordered []string
+
+	add := func(syncPolicies []policy.SyncPolicy) {
+		for _, sp := range syncPolicies {
+			if !names.Has(sp.Name) {
+				names.Insert(sp.Name)
+				ordered = append(ordered, sp.Name)
+			}
 		}
 	}
 
-	return max
+	add(spec.SyncPeriod)
+	for _, pool := range spec.NodePools {
+		add(pool.SyncPeriod)
+	}
+
+	return ordered
 }
@@ -0,0 +1,49 @@
+package annotator
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+// warmedUpObjects records the objects counted by warm-up, so that they are not counted again
+// when the informer replays them to the handler added afterwards.
+type warmedUpObjects map[types.UID]struct{}
+
+func (w warmedUpObjects) add(obj metav1.Object) {
+	w[obj.GetUID()] = struct{}{}
+}
+
+// counted returns true if obj has been counted by warm-up. Each object is replayed once,
+// so it is forgotten once checked.
+func (w warmedUpObjects) counted(obj metav1.Object) bool {
+	if _, ok := w[obj.GetUID()]; !ok {
+		return false
+	}
+	delete(w, obj.GetUID())
+
+	return true
+}
+
+// forget drops obj deleted before it is replayed, which is either an object or a tombstone.
+func (w warmedUpObjects) forget(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if o, ok := obj.(metav1.Object); ok {
+		delete(w, o.GetUID())
+	}
+}
+
+// getWarmUpTimeline returns the unix time before which bindings are too old to count for
+// hot value.
+func (c *Controller) getWarmUpTimeline() int64 {
+	timeRange := getMaxHotVauleTimeRange(c.getPolicy().Spec)
+	if timeRange <= 0 {
+		timeRange = DefaultBindingTimeRange
+	}
+
+	return time.Now().UTC().Unix() - int64(timeRange.Seconds())
+}
@@ -0,0 +1,71 @@
+package annotator
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	policy "github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+func newScheduledPod(name, nodeName string, scheduledTime time.Time) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, UID: types.UID(name)},
+		Spec:       v1.PodSpec{NodeName: nodeName},
+	}
+	if nodeName != "" {
+		pod.Status.Conditions = []v1.PodCondition{
+			{Type: v1.PodScheduled, Status: v1.ConditionTrue, LastTransitionTime: metav1.NewTime(scheduledTime)},
+		}
+	}
+
+	return pod
+}
+
+func TestPodBindingWarmUp(t *testing.T) {
+	now := time.Now()
+	pods := []*v1.Pod{
+		newScheduledPod("recent", "node1", now.Add(-30*time.Second)),
+		newScheduledPod("old", "node1", now.Add(-time.Hour)),
+		newScheduledPod("pending", "", time.Time{}),
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, pod := range pods {
+		indexer.Add(pod)
+	}
+
+	p := policy.DynamicSchedulerPolicy{Spec: policy.PolicySpec{
+		HotValue: []policy.HotValuePolicy{{TimeRange: metav1.Duration{Duration: 5 * time.Minute}, Count: 5}},
+	}}
+	c := &Controller{
+		podLister:      corelisters.NewPodLister(indexer),
+		policy:         p,
+		bindingRecords: NewBindingRecords(5 * time.Minute),
+	}
+
+	pc := newPodController(c)
+	pc.warmUp()
+	if got := c.bindingRecords.GetLastNodeBindingCount("node1", 5*time.Minute); got != 1 {
+		t.Fatalf("GetLastNodeBindingCount() = %d after warm-up, want 1", got)
+	}
+
+	// the pods replayed to the handler are not counted again.
+	for _, pod := range pods {
+		pc.handleAddPod(pod)
+	}
+	if got := c.bindingRecords.GetLastNodeBindingCount("node1", 5*time.Minute); got != 1 {
+		t.Errorf("GetLastNodeBindingCount() = %d after replay, want 1", got)
+	}
+
+	bound := newScheduledPod("pending", "node1", now)
+	pc.handleUpdatePod(pods[2], bound)
+	if got := c.bindingRecords.GetLastNodeBindingCount("node1", 5*time.Minute); got != 2 {
+		t.Errorf("GetLastNodeBindingCount() = %d after binding, want 2", got)
+	}
+}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+	"github.com/gocrane/crane-scheduler/pkg/utils"
+)
+
+type metricsServerProvider struct {
+	client metricsclientset.Interface
+}
+
+// NewMetricsServerProvider returns a MetricsProvider which gets node usage from metrics.k8s.io API.
+// As metrics-server only serves the latest usage, metrics with the same resource prefix, such
+// as cpu_usage_avg_5m and cpu_usage_max_avg_1h, share the same value.
+func NewMetricsServerProvider(client metricsclientset.Interface) MetricsProvider {
+	return &metricsServerProvider{
+		client: client,
+	}
+}
+
+func (m *metricsServerProvider) QueryByNode(metric policy.SyncPolicy, node *v1.Node) (*Sample, error) {
+	klog.V(4).Infof("Try to query %s of node[%s] from metrics-server", metric.Name, node.Name)
+
+	resourceName, err := resourceNameOfMetric(metric.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeMetrics, err := m.client.MetricsV1beta1().NodeMetricses().Get(context.TODO(), node.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return usageRatio(nodeMetrics, node, resourceName)
+}
+
+func (m *metricsServerProvider) QueryAllNodes(metric policy.SyncPolicy, nodes []*v1.Node) (map[string]*Sample, error) {
+	klog.V(4).Infof("Try to query %s of all nodes from metrics-server", metric.Name)
+
+	resourceName, err := resourceNameOfMetric(metric.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeMetricsList, err := m.client.MetricsV1beta1().NodeMetricses().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	nodeMetricsByName := make(map[string]*metricsv1beta1.NodeMetrics, len(nodeMetricsList.Items))
+	for i := range nodeMetricsList.Items {
+		nodeMetricsByName[nodeMetricsList.Items[i].Name] = &nodeMetricsList.Items[i]
+	}
+
+	samples := map[string]*Sample{}
+	for _, node := range nodes {
+		nodeMetrics, ok := nodeMetricsByName[node.Name]
+		if !ok {
+			continue
+		}
+
+		sample, err := usageRatio(nodeMetrics, node, resourceName)
+		if err != nil {
+			klog.V(4).Infof("Skip node[%s]: %v", node.Name, err)
+			continue
+		}
+		samples[node.Name] = sample
+	}
+
+	return samples, nil
+}
+
+func usageRatio(nodeMetrics *metricsv1beta1.NodeMetrics, node *v1.Node, resourceName v1.ResourceName) (*Sample, error) {
+	usage, ok := nodeMetrics.Usage[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("%s usage of node[%s] not found", resourceName, node.Name)
+	}
+
+	capacity, ok := node.Status.Capacity[resourceName]
+	if !ok || capacity.IsZero() {
+		return nil, fmt.Errorf("%s capacity of node[%s] not found", resourceName, node.Name)
+	}
+
+	return &Sample{
+		Value:     float64(usage.MilliValue()) / float64(capacity.MilliValue()),
+		Timestamp: nodeMetrics.Timestamp.Time,
+	}, nil
+}
+
+// resourceNameOfMetric returns the resource a usage metric refers to, e.g. cpu_usage_avg_5m refers to cpu.
+func resourceNameOfMetric(metricName string) (v1.ResourceName, error) {
+	if resourceName, ok := utils.GetResourceNameOfMetric(metricName); ok {
+		return resourceName, nil
+	}
+
+	return "", fmt.Errorf("metric %s is not supported by metrics-server", metricName)
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+func TestMetricsServerProvider_QueryByNode(t *testing.T) {
+	timestamp := metav1.NewTime(time.Now().Truncate(time.Second))
+
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: v1.NodeStatus{
+			Capacity: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("4"),
+				v1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+
+	nodeMetrics := &metricsv1beta1.NodeMetrics{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Timestamp:  timestamp,
+		Usage: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("1"),
+			v1.ResourceMemory: resource.MustParse("6Gi"),
+		},
+	}
+
+	// NodeMetrics is served as resource nodes, which can not be guessed by the object tracker.
+	client := &fake.Clientset{}
+	client.AddReactor("get", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nodeMetrics, nil
+	})
+
+	provider := NewMetricsServerProvider(client)
+
+	tests := []struct {
+		metricName string
+		want       float64
+		wantErr    bool
+	}{
+		{metricName: "cpu_usage_avg_5m", want: 0.25},
+		{metricName: "mem_usage_max_avg_1h", want: 0.75},
+		{metricName: "disk_usage_avg_5m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.metricName, func(t *testing.T) {
+			sample, err := provider.QueryByNode(policy.SyncPolicy{Name: tt.metricName}, node)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("QueryByNode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if sample.Value != tt.want {
+				t.Errorf("QueryByNode() value = %v, want %v", sample.Value, tt.want)
+			}
+			if !sample.Timestamp.Equal(timestamp.Time) {
+				t.Errorf("QueryByNode() timestamp = %v, want %v", sample.Timestamp, timestamp.Time)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+// StaticMetrics is the content of static metrics file, which maps node names to metric values.
+// For example:
+//
+//	nodes:
+//	  node-1:
+//	    cpu_usage_avg_5m: 0.3
+//	    mem_usage_avg_5m: 0.5
+type StaticMetrics struct {
+	Nodes map[string]map[string]float64 `json:"nodes"`
+}
+
+type staticProvider struct {
+	path string
+}
+
+// NewStaticProvider returns a MetricsProvider which reads metrics from a local file. The file is
+// read on every query, so it can be modified at run time.
+func NewStaticProvider(path string) MetricsProvider {
+	return &staticProvider{
+		path: path,
+	}
+}
+
+func (s *staticProvider) QueryByNode(metric policy.SyncPolicy, node *v1.Node) (*Sample, error) {
+	staticMetrics, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := staticMetrics.Nodes[node.Name][metric.Name]
+	if !ok {
+		return nil, fmt.Errorf("metric %s of node[%s] not found in %s", metric.Name, node.Name, s.path)
+	}
+
+	return &Sample{
+		Value:     value,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+func (s *staticProvider) QueryAllNodes(metric policy.SyncPolicy, nodes []*v1.Node) (map[string]*Sample, error) {
+	staticMetrics, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	samples, now := map[string]*Sample{}, time.Now()
+	for _, node := range nodes {
+		if value, ok := staticMetrics.Nodes[node.Name][metric.Name]; ok {
+			samples[node.Name] = &Sample{Value: value, Timestamp: now}
+		}
+	}
+
+	return samples, nil
+}
+
+func (s *staticProvider) load() (*StaticMetrics, error) {
+	data, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	staticMetrics := &StaticMetrics{}
+	if err := yaml.Unmarshal(data, staticMetrics); err != nil {
+		return nil, fmt.Errorf("failed to parse static metrics file %s: %v", s.path, err)
+	}
+
+	return staticMetrics, nil
+}
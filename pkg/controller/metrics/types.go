@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+const (
+	// ProviderPrometheus gets metrics from Prometheus.
+	ProviderPrometheus = "prometheus"
+	// ProviderMetricsServer gets metrics from metrics.k8s.io API, which is served by metrics-server.
+	ProviderMetricsServer = "metrics-server"
+	// ProviderStatic gets metrics from a local file, which is used for testing.
+	ProviderStatic = "static"
+)
+
+// Sample is a value of node metric at the given timestamp.
+type Sample struct {
+	Value     float64
+	Timestamp time.Time
+}
+
+// MetricsProvider provides load metrics of nodes, where usage metrics are ratio in range [0, 1].
+type MetricsProvider interface {
+	// QueryByNode queries the latest sample of metric for the node.
+	QueryByNode(metric policy.SyncPolicy, node *v1.Node) (*Sample, error)
+}
+
+// BatchMetricsProvider provides load metrics of all nodes with a single query.
+type BatchMetricsProvider interface {
+	MetricsProvider
+	// QueryAllNodes queries the latest samples of metric for the given nodes, keyed by node name.
+	// Nodes without data are absent from the result.
+	QueryAllNodes(metric policy.SyncPolicy, nodes []*v1.Node) (map[string]*Sample, error)
+}
@@ -0,0 +1,138 @@
+package prometheus
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/api"
+	config "github.com/prometheus/common/config"
+)
+
+// ClientConfig holds the options to connect to Prometheus.
+type ClientConfig struct {
+	// Addresses are the endpoints of Prometheus, such as Prometheus replicas or Thanos/Cortex
+	// gateways. They are tried in order, and the next one is used when the former fails.
+	Addresses []string
+	// QueryTimeout is the timeout of each query against a single endpoint.
+	QueryTimeout time.Duration
+
+	// TLSCAFile is the CA certificate used to verify the server certificate.
+	TLSCAFile string
+	// TLSCertFile and TLSKeyFile are the client certificate and key used for mTLS.
+	TLSCertFile string
+	TLSKeyFile  string
+	// TLSServerName is used to verify the hostname of server certificate.
+	TLSServerName string
+	// TLSInsecureSkipVerify disables the verification of server certificate.
+	TLSInsecureSkipVerify bool
+
+	// BearerTokenFile is the file containing the bearer token, which is re-read on each request.
+	BearerTokenFile string
+	// BasicAuthUsername and BasicAuthPasswordFile are the credentials of HTTP basic authentication.
+	BasicAuthUsername     string
+	BasicAuthPasswordFile string
+
+	// Headers are added to each request, such as the tenant header of Thanos/Cortex.
+	Headers map[string]string
+}
+
+// Validate checks whether the config is valid.
+func (c *ClientConfig) Validate() error {
+	if len(c.Addresses) == 0 {
+		return fmt.Errorf("at least one prometheus address is required")
+	}
+
+	if c.QueryTimeout <= 0 {
+		return fmt.Errorf("prometheus query timeout must be greater than 0")
+	}
+
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return fmt.Errorf("prometheus tls cert file and key file must be set together")
+	}
+
+	httpConfig := c.httpClientConfig()
+	return httpConfig.Validate()
+}
+
+// httpClientConfig converts the config into the HTTPClientConfig of Prometheus.
+func (c *ClientConfig) httpClientConfig() config.HTTPClientConfig {
+	httpConfig := config.HTTPClientConfig{
+		TLSConfig: config.TLSConfig{
+			CAFile:             c.TLSCAFile,
+			CertFile:           c.TLSCertFile,
+			KeyFile:            c.TLSKeyFile,
+			ServerName:         c.TLSServerName,
+			InsecureSkipVerify: c.TLSInsecureSkipVerify,
+		},
+		FollowRedirects: true,
+	}
+
+	if c.BearerTokenFile != "" {
+		httpConfig.Authorization = &config.Authorization{
+			Type:            "Bearer",
+			CredentialsFile: c.BearerTokenFile,
+		}
+	}
+
+	if c.BasicAuthUsername != "" || c.BasicAuthPasswordFile != "" {
+		httpConfig.BasicAuth = &config.BasicAuth{
+			Username:     c.BasicAuthUsername,
+			PasswordFile: c.BasicAuthPasswordFile,
+		}
+	}
+
+	return httpConfig
+}
+
+// newRoundTripper creates the http.RoundTripper shared by all endpoints.
+func (c *ClientConfig) newRoundTripper() (http.RoundTripper, error) {
+	rt, err := config.NewRoundTripperFromConfig(c.httpClientConfig(), "crane-scheduler-controller")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(c.Headers) > 0 {
+		rt = &headerRoundTripper{headers: c.Headers, rt: rt}
+	}
+
+	return rt, nil
+}
+
+// newAPIClients creates an api.Client for each endpoint.
+func (c *ClientConfig) newAPIClients() ([]api.Client, error) {
+	rt, err := c.newRoundTripper()
+	if err != nil {
+		return nil, err
+	}
+
+	clients := make([]api.Client, 0, len(c.Addresses))
+	for _, addr := range c.Addresses {
+		client, err := api.NewClient(api.Config{
+			Address:      addr,
+			RoundTripper: rt,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client of prometheus %s: %v", addr, err)
+		}
+		clients = append(clients, client)
+	}
+
+	return clients, nil
+}
+
+// headerRoundTripper adds custom headers to each request.
+type headerRoundTripper struct {
+	headers map[string]string
+	rt      http.RoundTripper
+}
+
+func (h *headerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTripper must not modify the original request.
+	req = req.Clone(req.Context())
+	for key, value := range h.headers {
+		req.Header.Set(key, value)
+	}
+
+	return h.rt.RoundTrip(req)
+}
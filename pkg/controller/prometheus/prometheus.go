@@ -1,128 +1,278 @@
 package prometheus
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math"
-	"strconv"
+	"net"
+	"sync"
+	"text/template"
 	"time"
 
-	"github.com/prometheus/client_golang/api"
 	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
+
+	"github.com/gocrane/crane-scheduler/pkg/controller/metrics"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
 )
 
 const (
+	// DefaultPrometheusQueryTimeout is the default timeout of each query against a single endpoint.
 	DefaultPrometheusQueryTimeout = 10 * time.Second
+	// DefaultQueryTemplate queries the recording rule named after the metric.
+	DefaultQueryTemplate = "{{ .Metric }}{{ .Selector }}"
+	// DefaultNodeLabel is the label identifying node in the result of recording rules.
+	DefaultNodeLabel = string(model.InstanceLabel)
+	// DefaultScale converts the percentages produced by recording rules to ratio.
+	DefaultScale = 0.01
 )
 
 // PromClient provides client to interact with Prometheus.
 type PromClient interface {
-	// QueryByNodeIP queries data by node IP.
-	QueryByNodeIP(string, string) (string, error)
-	// QueryByNodeName queries data by node IP.
-	QueryByNodeName(string, string) (string, error)
-	// QueryByNodeIPWithOffset queries data by node IP with offset.
-	QueryByNodeIPWithOffset(string, string, string) (string, error)
+	metrics.BatchMetricsProvider
 }
 
 type promClient struct {
-	API v1.API
+	// apis are the clients of Prometheus endpoints in the order of failover.
+	apis         []v1.API
+	queryTimeout time.Duration
+
+	// templates caches the parsed query templates, keyed by template text.
+	templates sync.Map
+}
+
+// queryParams are the fields which can be referred to by query templates.
+type queryParams struct {
+	// Metric is the name of metric.
+	Metric string
+	// Selector is the label selector of node, such as {instance=~"10.0.0.1(:.+)?"}.
+	Selector string
+	// LabelMatcher is the label matcher of node without braces, such as instance=~"10.0.0.1(:.+)?".
+	LabelMatcher string
 }
 
 // NewPromClient returns PromClient interface.
-func NewPromClient(addr string) (PromClient, error) {
-	config := api.Config{
-		Address: addr,
+func NewPromClient(config *ClientConfig) (PromClient, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
 	}
 
-	client, err := api.NewClient(config)
+	clients, err := config.newAPIClients()
 	if err != nil {
 		return nil, err
 	}
 
+	apis := make([]v1.API, 0, len(clients))
+	for _, client := range clients {
+		apis = append(apis, v1.NewAPI(client))
+	}
+
 	return &promClient{
-		API: v1.NewAPI(client),
+		apis:         apis,
+		queryTimeout: config.QueryTimeout,
 	}, nil
 }
 
-func (p *promClient) QueryByNodeIP(metricName, ip string) (string, error) {
-	klog.V(4).Infof("Try to query %s by node IP[%s]", metricName, ip)
+// QueryByNode queries data by node IP, and falls back to node name.
+func (p *promClient) QueryByNode(metric policy.SyncPolicy, node *corev1.Node) (*metrics.Sample, error) {
+	klog.V(4).Infof("Try to query %s of node[%s]", metric.Name, node.Name)
 
-	querySelector := fmt.Sprintf("%s{instance=~\"%s\"} /100", metricName, ip)
+	var lastErr error
+	for _, instance := range []string{getNodeInternalIP(node), node.Name} {
+		labelMatcher := fmt.Sprintf("%s=~\"%s(:.+)?\"", getNodeLabel(metric), instance)
 
-	result, err := p.query(querySelector)
-	if result != "" && err == nil {
-		return result, nil
-	}
+		query, err := p.buildQuery(metric, labelMatcher)
+		if err != nil {
+			return nil, err
+		}
+
+		sample, err := p.query(query)
+		if err == nil && sample != nil {
+			return scaleSample(metric, sample), nil
+		}
 
-	querySelector = fmt.Sprintf("%s{instance=~\"%s:.+\"} /100", metricName, ip)
-	result, err = p.query(querySelector)
-	if result != "" && err == nil {
-		return result, nil
+		lastErr = err
+		if err == nil {
+			lastErr = fmt.Errorf("no data returned by query %s", query)
+		}
 	}
 
-	return "", err
+	return nil, fmt.Errorf("failed to get data %s{%s}: %v", metric.Name, node.Name, lastErr)
 }
 
-func (p *promClient) QueryByNodeName(metricName, name string) (string, error) {
-	klog.V(4).Infof("Try to query %s by node name[%s]", metricName, name)
+// QueryAllNodes queries data of all nodes with a single query, and maps the series back to
+// nodes by the node label, whose value is either node IP or node name, with an optional port.
+func (p *promClient) QueryAllNodes(metric policy.SyncPolicy, nodes []*corev1.Node) (map[string]*metrics.Sample, error) {
+	klog.V(4).Infof("Try to query %s of all nodes", metric.Name)
+
+	query, err := p.buildQuery(metric, "")
+	if err != nil {
+		return nil, err
+	}
+
+	vector, err := p.queryVector(query)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeNameByInstance := make(map[string]string, 2*len(nodes))
+	for _, node := range nodes {
+		nodeNameByInstance[node.Name] = node.Name
+		nodeNameByInstance[getNodeInternalIP(node)] = node.Name
+	}
+
+	nodeLabel := model.LabelName(getNodeLabel(metric))
+
+	samples := map[string]*metrics.Sample{}
+	for _, elem := range vector {
+		instance := string(elem.Metric[nodeLabel])
+		if host, _, err := net.SplitHostPort(instance); err == nil {
+			instance = host
+		}
 
-	querySelector := fmt.Sprintf("%s{instance=~\"%s\"} /100", metricName, name)
+		nodeName, ok := nodeNameByInstance[instance]
+		if !ok {
+			continue
+		}
 
-	result, err := p.query(querySelector)
-	if result != "" && err == nil {
-		return result, nil
+		samples[nodeName] = scaleSample(metric, toSample(elem))
 	}
 
-	return "", err
+	return samples, nil
 }
 
-func (p *promClient) QueryByNodeIPWithOffset(metricName, ip, offset string) (string, error) {
-	klog.V(4).Info("Try to query %s with offset %s by node IP[%s]", metricName, offset, ip)
+// buildQuery renders the query template of metric with the label matcher of node.
+func (p *promClient) buildQuery(metric policy.SyncPolicy, labelMatcher string) (string, error) {
+	text := metric.Query
+	if text == "" {
+		text = DefaultQueryTemplate
+	}
+
+	var tmpl *template.Template
+	if cached, ok := p.templates.Load(text); ok {
+		tmpl = cached.(*template.Template)
+	} else {
+		parsed, err := template.New(metric.Name).Parse(text)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse query template of %s: %v", metric.Name, err)
+		}
+		p.templates.Store(text, parsed)
+		tmpl = parsed
+	}
 
-	querySelector := fmt.Sprintf("%s{instance=~\"%s\"} offset %s /100", metricName, ip, offset)
-	result, err := p.query(querySelector)
-	if result != "" && err == nil {
-		return result, nil
+	params := queryParams{
+		Metric:       metric.Name,
+		LabelMatcher: labelMatcher,
+	}
+	if labelMatcher != "" {
+		params.Selector = "{" + labelMatcher + "}"
 	}
 
-	querySelector = fmt.Sprintf("%s{instance=~\"%s:.+\"} offset %s /100", metricName, ip, offset)
-	result, err = p.query(querySelector)
-	if result != "" && err == nil {
-		return result, nil
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, params); err != nil {
+		return "", fmt.Errorf("failed to render query template of %s: %v", metric.Name, err)
 	}
 
-	return "", err
+	return buf.String(), nil
 }
 
-func (p *promClient) query(query string) (string, error) {
+func (p *promClient) query(query string) (*metrics.Sample, error) {
+	vector, err := p.queryVector(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var sample *metrics.Sample
+	for _, elem := range vector {
+		sample = toSample(elem)
+	}
+
+	return sample, nil
+}
+
+// queryVector queries the endpoints in order until one of them succeeds.
+func (p *promClient) queryVector(query string) (model.Vector, error) {
 	klog.V(4).Infof("Begin to query prometheus by promQL [%s]...", query)
 
-	ctx, cancel := context.WithTimeout(context.Background(), DefaultPrometheusQueryTimeout)
+	var lastErr error
+	for i, api := range p.apis {
+		vector, err := p.queryVectorFrom(api, query)
+		if err == nil {
+			return vector, nil
+		}
+
+		if i < len(p.apis)-1 {
+			klog.V(4).Infof("Failed to query prometheus endpoint %d, try the next one: %v", i, err)
+		}
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+func (p *promClient) queryVectorFrom(api v1.API, query string) (model.Vector, error) {
+	timeout := p.queryTimeout
+	if timeout <= 0 {
+		timeout = DefaultPrometheusQueryTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	result, warnings, err := p.API.Query(ctx, query, time.Now())
+	result, warnings, err := api.Query(ctx, query, time.Now())
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if len(warnings) > 0 {
-		return "", fmt.Errorf("unexpected warnings: %v", warnings)
+		return nil, fmt.Errorf("unexpected warnings: %v", warnings)
 	}
 
 	if result.Type() != model.ValVector {
-		return "", fmt.Errorf("illege result type: %v", result.Type())
+		return nil, fmt.Errorf("illege result type: %v", result.Type())
+	}
+
+	return result.(model.Vector), nil
+}
+
+func toSample(elem *model.Sample) *metrics.Sample {
+	value := float64(elem.Value)
+	if value < float64(0) || math.IsNaN(value) {
+		value = 0
+	}
+
+	return &metrics.Sample{
+		Value:     value,
+		Timestamp: elem.Timestamp.Time(),
 	}
+}
+
+func scaleSample(metric policy.SyncPolicy, sample *metrics.Sample) *metrics.Sample {
+	scale := metric.Scale
+	if scale == 0 {
+		scale = DefaultScale
+	}
+
+	sample.Value *= scale
+	return sample
+}
+
+func getNodeLabel(metric policy.SyncPolicy) string {
+	if metric.NodeLabel == "" {
+		return DefaultNodeLabel
+	}
+	return metric.NodeLabel
+}
 
-	var metricValue string
-	for _, elem := range result.(model.Vector) {
-		if float64(elem.Value) < float64(0) || math.IsNaN(float64(elem.Value)) {
-			elem.Value = 0
+func getNodeInternalIP(node *corev1.Node) string {
+	for _, addr := range node.Status.Addresses {
+		if addr.Type == corev1.NodeInternalIP {
+			return addr.Address
 		}
-		metricValue = strconv.FormatFloat(float64(elem.Value), 'f', 5, 64)
 	}
 
-	return metricValue, nil
+	return node.Name
 }
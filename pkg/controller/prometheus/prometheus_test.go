@@ -0,0 +1,212 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+type fakeAPI struct {
+	v1.API
+	result  model.Value
+	err     error
+	queries []string
+}
+
+func (f *fakeAPI) Query(ctx context.Context, query string, ts time.Time) (model.Value, v1.Warnings, error) {
+	f.queries = append(f.queries, query)
+	return f.result, nil, f.err
+}
+
+func newNode(name, ip string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: ip}},
+		},
+	}
+}
+
+func newSample(instance string, value float64) *model.Sample {
+	return &model.Sample{
+		Metric: model.Metric{model.InstanceLabel: model.LabelValue(instance)},
+		Value:  model.SampleValue(value),
+	}
+}
+
+func TestPromClient_QueryAllNodes(t *testing.T) {
+	api := &fakeAPI{
+		result: model.Vector{
+			newSample("10.0.0.1:9100", 10),
+			newSample("node-2", 20),
+			newSample("10.0.0.3", -1),
+			newSample("10.0.0.9:9100", 90),
+		},
+	}
+	client := &promClient{apis: []v1.API{api}}
+
+	nodes := []*corev1.Node{
+		newNode("node-1", "10.0.0.1"),
+		newNode("node-2", "10.0.0.2"),
+		newNode("node-3", "10.0.0.3"),
+		newNode("node-4", "10.0.0.4"),
+	}
+
+	samples, err := client.QueryAllNodes(policy.SyncPolicy{Name: "cpu_usage_avg_5m"}, nodes)
+	if err != nil {
+		t.Fatalf("QueryAllNodes() error = %v", err)
+	}
+
+	if len(api.queries) != 1 || api.queries[0] != "cpu_usage_avg_5m" {
+		t.Errorf("QueryAllNodes() queries = %v, want a single query without instance filter", api.queries)
+	}
+
+	want := map[string]float64{"node-1": 0.1, "node-2": 0.2, "node-3": 0}
+	if len(samples) != len(want) {
+		t.Fatalf("QueryAllNodes() got %d samples, want %d", len(samples), len(want))
+	}
+	for name, value := range want {
+		sample, ok := samples[name]
+		if !ok {
+			t.Errorf("QueryAllNodes() sample of %s not found", name)
+			continue
+		}
+		if sample.Value != value {
+			t.Errorf("QueryAllNodes() sample of %s = %v, want %v", name, sample.Value, value)
+		}
+	}
+}
+
+func TestPromClient_buildQuery(t *testing.T) {
+	client := &promClient{}
+
+	tests := []struct {
+		name         string
+		metric       policy.SyncPolicy
+		labelMatcher string
+		want         string
+	}{
+		{
+			name:         "default template",
+			metric:       policy.SyncPolicy{Name: "cpu_usage_avg_5m"},
+			labelMatcher: `instance=~"10.0.0.1(:.+)?"`,
+			want:         `cpu_usage_avg_5m{instance=~"10.0.0.1(:.+)?"}`,
+		},
+		{
+			name:   "default template for all nodes",
+			metric: policy.SyncPolicy{Name: "cpu_usage_avg_5m"},
+			want:   `cpu_usage_avg_5m`,
+		},
+		{
+			name: "custom template with label matcher",
+			metric: policy.SyncPolicy{
+				Name:  "mem_usage_avg_5m",
+				Query: `avg_over_time(node:memory_utilisation:ratio{job="node-exporter",{{ .LabelMatcher }}}[5m])`,
+			},
+			labelMatcher: `node=~"node-1(:.+)?"`,
+			want:         `avg_over_time(node:memory_utilisation:ratio{job="node-exporter",node=~"node-1(:.+)?"}[5m])`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.buildQuery(tt.metric, tt.labelMatcher)
+			if err != nil {
+				t.Fatalf("buildQuery() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("buildQuery() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromClient_QueryAllNodesWithNodeLabel(t *testing.T) {
+	api := &fakeAPI{
+		result: model.Vector{
+			&model.Sample{
+				Metric: model.Metric{"instance": "10.0.0.9:9100", "kubernetes_node": "node-1"},
+				Value:  0.5,
+			},
+		},
+	}
+	client := &promClient{apis: []v1.API{api}}
+
+	metric := policy.SyncPolicy{Name: "cpu_usage_avg_5m", NodeLabel: "kubernetes_node", Scale: 1}
+
+	samples, err := client.QueryAllNodes(metric, []*corev1.Node{newNode("node-1", "10.0.0.1")})
+	if err != nil {
+		t.Fatalf("QueryAllNodes() error = %v", err)
+	}
+
+	if sample, ok := samples["node-1"]; !ok || sample.Value != 0.5 {
+		t.Errorf("QueryAllNodes() samples = %v, want node-1 with value 0.5", samples)
+	}
+}
+
+func TestPromClient_QueryFailover(t *testing.T) {
+	primary := &fakeAPI{err: fmt.Errorf("service unavailable")}
+	secondary := &fakeAPI{result: model.Vector{newSample("10.0.0.1", 10)}}
+	client := &promClient{apis: []v1.API{primary, secondary}}
+
+	sample, err := client.QueryByNode(policy.SyncPolicy{Name: "cpu_usage_avg_5m"}, newNode("node-1", "10.0.0.1"))
+	if err != nil {
+		t.Fatalf("QueryByNode() error = %v", err)
+	}
+
+	if len(primary.queries) != 1 || len(secondary.queries) != 1 {
+		t.Errorf("QueryByNode() queried primary %d times and secondary %d times, want once each", len(primary.queries), len(secondary.queries))
+	}
+	if sample.Value != 0.1 {
+		t.Errorf("QueryByNode() = %v, want 0.1", sample.Value)
+	}
+}
+
+func TestNewPromClient_AuthAndHeaders(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("secret"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var gotAuth, gotTenant string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth, gotTenant = r.Header.Get("Authorization"), r.Header.Get("X-Scope-OrgID")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"instance":"node-1"},"value":[1,"50"]}]}}`)
+	}))
+	defer server.Close()
+
+	client, err := NewPromClient(&ClientConfig{
+		Addresses:       []string{"http://127.0.0.1:1", server.URL},
+		QueryTimeout:    time.Second,
+		BearerTokenFile: tokenFile,
+		Headers:         map[string]string{"X-Scope-OrgID": "tenant-1"},
+	})
+	if err != nil {
+		t.Fatalf("NewPromClient() error = %v", err)
+	}
+
+	samples, err := client.QueryAllNodes(policy.SyncPolicy{Name: "cpu_usage_avg_5m"}, []*corev1.Node{newNode("node-1", "10.0.0.1")})
+	if err != nil {
+		t.Fatalf("QueryAllNodes() error = %v", err)
+	}
+
+	if gotAuth != "Bearer secret" || gotTenant != "tenant-1" {
+		t.Errorf("request headers Authorization = %q, X-Scope-OrgID = %q", gotAuth, gotTenant)
+	}
+	if sample, ok := samples["node-1"]; !ok || sample.Value != 0.5 {
+		t.Errorf("QueryAllNodes() samples = %v, want node-1 with value 0.5", samples)
+	}
+}
@@ -11,8 +11,58 @@ type DynamicArgs struct {
 	metav1.TypeMeta
 	// PolicyConfigPath specified the path of policy config.
 	PolicyConfigPath string
+	// PolicyName specified the name of DynamicSchedulerPolicy object, which takes
+	// precedence over PolicyConfigPath if set.
+	PolicyName string
+	// EnableNodeLoad enables reading node load from NodeLoad objects, which falls back
+	// to node annotations if the metric is not found or expired.
+	EnableNodeLoad bool
+	// ClockSkewTolerance is the tolerated clock skew between scheduler and node annotator.
+	// Load data is considered up to ClockSkewTolerance newer than its timestamp, while
+	// data whose timestamp is more than ClockSkewTolerance in the future is ignored.
+	// 0 disables the tolerance.
+	ClockSkewTolerance metav1.Duration
+	// LegacyTimestamps interprets the timestamps of node annotations in the legacy format
+	// 2006-01-02T15:04:05Z as local time in the time zone of TZ, rather than RFC3339 in UTC.
+	LegacyTimestamps bool
+	// ScoreNormalization specified how to normalize the scores of candidate nodes, which
+	// is one of None, MinMax and Rank.
+	ScoreNormalization ScoreNormalizationStrategy
+	// EstimatePodUsage enables filtering and scoring nodes by the projected usage after
+	// placement, which adds the expected usage of pod relative to node capacity to the usage
+	// of node. The expected usage is taken from pod annotation scheduler.crane.io/expected-usage,
+	// the historical usage of workload in annotation scheduler.crane.io/workload-usage of the
+	// ReplicaSet or StatefulSet of pod, or pod requests, in the order of precedence.
+	EstimatePodUsage bool
+	// ShadowMode makes the plugin dry-run, Filter never rejects nodes but records the nodes
+	// it would have rejected in metrics, and Score computes scores but returns 0.
+	ShadowMode bool
+	// ShadowModeEvents records the nodes which would have been rejected in shadow mode as
+	// events of pods as well.
+	ShadowModeEvents bool
+	// ThresholdRelaxationDelay is how long a pod stays pending before the load thresholds of
+	// predicates with relaxation are relaxed for it, by one more step every such duration.
+	// 0 disables the relaxation.
+	ThresholdRelaxationDelay metav1.Duration
+	// HotValueWeight is the factor multiplied to the hot value of node, which is subtracted
+	// from the score of node.
+	HotValueWeight float64
 }
 
+// ScoreNormalizationStrategy is the strategy to normalize the scores of Dynamic plugin.
+type ScoreNormalizationStrategy string
+
+const (
+	// ScoreNormalizationNone keeps the scores as they are.
+	ScoreNormalizationNone ScoreNormalizationStrategy = "None"
+	// ScoreNormalizationMinMax scales the scores linearly, so that the lowest one becomes
+	// the min node score and the highest one becomes the max node score.
+	ScoreNormalizationMinMax ScoreNormalizationStrategy = "MinMax"
+	// ScoreNormalizationRank scores nodes by the rank of their scores, which are spread
+	// evenly over the range of node score.
+	ScoreNormalizationRank ScoreNormalizationStrategy = "Rank"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // NodeResourceTopologyMatchArgs holds arguments used to configure the NodeResourceTopologyMatch plugin.
@@ -20,4 +70,10 @@ type NodeResourceTopologyMatchArgs struct {
 	metav1.TypeMeta
 	// TopologyAwareResources represents the resource names of topology.
 	TopologyAwareResources []string
+	// PolicyConfigPath specified the path of the policy of Dynamic plugin, whose exemption
+	// rules are applied by Filter as well.
+	PolicyConfigPath string
+	// PolicyName specified the name of DynamicSchedulerPolicy object, which takes precedence
+	// over PolicyConfigPath if set.
+	PolicyName string
 }
@@ -1,13 +1,43 @@
 package v1beta2
 
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 var (
 	defaultNodeResource = []string{"cpu"}
+
+	defaultLegacyTimestamps = true
+
+	defaultThresholdRelaxationDelay = metav1.Duration{Duration: 5 * time.Minute}
+
+	defaultHotValueWeight = 10.
 )
 
 func SetDefaults_DynamicArgs(obj *DynamicArgs) {
 	if obj.PolicyConfigPath == "" {
 		obj.PolicyConfigPath = "/etc/kubernetes/dynamic-scheduler-policy.yaml"
 	}
+	if obj.ClockSkewTolerance == nil {
+		obj.ClockSkewTolerance = &metav1.Duration{}
+	}
+	if obj.LegacyTimestamps == nil {
+		legacyTimestamps := defaultLegacyTimestamps
+		obj.LegacyTimestamps = &legacyTimestamps
+	}
+	if obj.ScoreNormalization == "" {
+		obj.ScoreNormalization = ScoreNormalizationNone
+	}
+	if obj.ThresholdRelaxationDelay == nil {
+		delay := defaultThresholdRelaxationDelay
+		obj.ThresholdRelaxationDelay = &delay
+	}
+	if obj.HotValueWeight == nil {
+		weight := defaultHotValueWeight
+		obj.HotValueWeight = &weight
+	}
 	return
 }
 
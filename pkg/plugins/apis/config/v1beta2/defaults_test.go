@@ -0,0 +1,80 @@
+package v1beta2
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetDefaultsDynamicArgs(t *testing.T) {
+	zero, disabled := 0., false
+	tests := []struct {
+		name          string
+		args          *DynamicArgs
+		wantTolerance time.Duration
+		wantDelay     time.Duration
+		wantWeight    float64
+		wantLegacy    bool
+	}{
+		{
+			name:          "empty",
+			args:          &DynamicArgs{},
+			wantTolerance: 0,
+			wantDelay:     5 * time.Minute,
+			wantWeight:    10,
+			wantLegacy:    true,
+		},
+		{
+			name:          "clock skew tolerance",
+			args:          &DynamicArgs{ClockSkewTolerance: &metav1.Duration{Duration: time.Minute}},
+			wantTolerance: time.Minute,
+			wantDelay:     5 * time.Minute,
+			wantWeight:    10,
+			wantLegacy:    true,
+		},
+		{
+			name:          "zero threshold relaxation delay",
+			args:          &DynamicArgs{ThresholdRelaxationDelay: &metav1.Duration{}},
+			wantTolerance: 0,
+			wantDelay:     0,
+			wantWeight:    10,
+			wantLegacy:    true,
+		},
+		{
+			name:          "zero hot value weight",
+			args:          &DynamicArgs{HotValueWeight: &zero},
+			wantTolerance: 0,
+			wantDelay:     5 * time.Minute,
+			wantWeight:    0,
+			wantLegacy:    true,
+		},
+		{
+			name:          "legacy timestamps disabled",
+			args:          &DynamicArgs{LegacyTimestamps: &disabled},
+			wantTolerance: 0,
+			wantDelay:     5 * time.Minute,
+			wantWeight:    10,
+			wantLegacy:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDefaults_DynamicArgs(tt.args)
+
+			if got := tt.args.ClockSkewTolerance.Duration; got != tt.wantTolerance {
+				t.Errorf("ClockSkewTolerance = %v, want %v", got, tt.wantTolerance)
+			}
+			if got := tt.args.ThresholdRelaxationDelay.Duration; got != tt.wantDelay {
+				t.Errorf("ThresholdRelaxationDelay = %v, want %v", got, tt.wantDelay)
+			}
+			if got := *tt.args.HotValueWeight; got != tt.wantWeight {
+				t.Errorf("HotValueWeight = %v, want %v", got, tt.wantWeight)
+			}
+			if got := *tt.args.LegacyTimestamps; got != tt.wantLegacy {
+				t.Errorf("LegacyTimestamps = %v, want %v", got, tt.wantLegacy)
+			}
+		})
+	}
+}
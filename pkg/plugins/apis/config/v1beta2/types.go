@@ -11,8 +11,60 @@ type DynamicArgs struct {
 	metav1.TypeMeta `json:",inline"`
 	// PolicyConfigPath specified the path of policy config.
 	PolicyConfigPath string `json:"policyConfigPath"`
+	// PolicyName specified the name of DynamicSchedulerPolicy object, which takes
+	// precedence over PolicyConfigPath if set.
+	PolicyName string `json:"policyName,omitempty"`
+	// EnableNodeLoad enables reading node load from NodeLoad objects, which falls back
+	// to node annotations if the metric is not found or expired.
+	EnableNodeLoad bool `json:"enableNodeLoad,omitempty"`
+	// ClockSkewTolerance is the tolerated clock skew between scheduler and node annotator.
+	// Load data is considered up to ClockSkewTolerance newer than its timestamp, while
+	// data whose timestamp is more than ClockSkewTolerance in the future is ignored.
+	// Defaults to 0, which disables the tolerance and takes timestamps as they are.
+	ClockSkewTolerance *metav1.Duration `json:"clockSkewTolerance,omitempty"`
+	// LegacyTimestamps interprets the timestamps of node annotations in the legacy format
+	// 2006-01-02T15:04:05Z as local time in the time zone of TZ, which are written by node
+	// annotators of earlier versions. Otherwise they are RFC3339 in UTC. Defaults to true,
+	// and it can be set to false once no node annotator of earlier versions is running.
+	LegacyTimestamps *bool `json:"legacyTimestamps,omitempty"`
+	// ScoreNormalization specified how to normalize the scores of candidate nodes, which
+	// is one of None, MinMax and Rank. Defaults to None.
+	ScoreNormalization ScoreNormalizationStrategy `json:"scoreNormalization,omitempty"`
+	// EstimatePodUsage enables filtering and scoring nodes by the projected usage after
+	// placement, which adds the expected usage of pod relative to node capacity to the usage
+	// of node. The expected usage is taken from pod annotation scheduler.crane.io/expected-usage,
+	// the historical usage of workload in annotation scheduler.crane.io/workload-usage of the
+	// ReplicaSet or StatefulSet of pod, or pod requests, in the order of precedence.
+	EstimatePodUsage bool `json:"estimatePodUsage,omitempty"`
+	// ShadowMode makes the plugin dry-run, Filter never rejects nodes but records the nodes
+	// it would have rejected in metrics, and Score computes scores but returns 0.
+	ShadowMode bool `json:"shadowMode,omitempty"`
+	// ShadowModeEvents records the nodes which would have been rejected in shadow mode as
+	// events of pods as well.
+	ShadowModeEvents bool `json:"shadowModeEvents,omitempty"`
+	// ThresholdRelaxationDelay is how long a pod stays pending before the load thresholds of
+	// predicates with relaxation are relaxed for it, by one more step every such duration.
+	// Defaults to 5m, and 0 disables the relaxation.
+	ThresholdRelaxationDelay *metav1.Duration `json:"thresholdRelaxationDelay,omitempty"`
+	// HotValueWeight is the factor multiplied to the hot value of node, which is subtracted
+	// from the score of node. Defaults to 10, and 0 disables hot value.
+	HotValueWeight *float64 `json:"hotValueWeight,omitempty"`
 }
 
+// ScoreNormalizationStrategy is the strategy to normalize the scores of Dynamic plugin.
+type ScoreNormalizationStrategy string
+
+const (
+	// ScoreNormalizationNone keeps the scores as they are.
+	ScoreNormalizationNone ScoreNormalizationStrategy = "None"
+	// ScoreNormalizationMinMax scales the scores linearly, so that the lowest one becomes
+	// the min node score and the highest one becomes the max node score.
+	ScoreNormalizationMinMax ScoreNormalizationStrategy = "MinMax"
+	// ScoreNormalizationRank scores nodes by the rank of their scores, which are spread
+	// evenly over the range of node score.
+	ScoreNormalizationRank ScoreNormalizationStrategy = "Rank"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // NodeResourceTopologyMatchArgs holds arguments used to configure the NodeResourceTopologyMatch plugin.
@@ -20,4 +72,10 @@ type NodeResourceTopologyMatchArgs struct {
 	metav1.TypeMeta `json:",inline"`
 	// TopologyAwareResources represents the resource names of topology.
 	TopologyAwareResources []string `json:"topologyAwareResources,omitempty"`
+	// PolicyConfigPath specified the path of the policy of Dynamic plugin, whose exemption
+	// rules are applied by Filter as well.
+	PolicyConfigPath string `json:"policyConfigPath,omitempty"`
+	// PolicyName specified the name of DynamicSchedulerPolicy object, which takes precedence
+	// over PolicyConfigPath if set.
+	PolicyName string `json:"policyName,omitempty"`
 }
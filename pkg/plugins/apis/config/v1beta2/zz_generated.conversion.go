@@ -9,6 +9,7 @@ import (
 	unsafe "unsafe"
 
 	config "github.com/gocrane/crane-scheduler/pkg/plugins/apis/config"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -45,6 +46,24 @@ func RegisterConversions(s *runtime.Scheme) error {
 
 func autoConvert_v1beta2_DynamicArgs_To_config_DynamicArgs(in *DynamicArgs, out *config.DynamicArgs, s conversion.Scope) error {
 	out.PolicyConfigPath = in.PolicyConfigPath
+	out.PolicyName = in.PolicyName
+	out.EnableNodeLoad = in.EnableNodeLoad
+	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.ClockSkewTolerance, &out.ClockSkewTolerance, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.LegacyTimestamps, &out.LegacyTimestamps, s); err != nil {
+		return err
+	}
+	out.ScoreNormalization = config.ScoreNormalizationStrategy(in.ScoreNormalization)
+	out.EstimatePodUsage = in.EstimatePodUsage
+	out.ShadowMode = in.ShadowMode
+	out.ShadowModeEvents = in.ShadowModeEvents
+	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.ThresholdRelaxationDelay, &out.ThresholdRelaxationDelay, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_float64_To_float64(&in.HotValueWeight, &out.HotValueWeight, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -55,6 +74,24 @@ func Convert_v1beta2_DynamicArgs_To_config_DynamicArgs(in *DynamicArgs, out *con
 
 func autoConvert_config_DynamicArgs_To_v1beta2_DynamicArgs(in *config.DynamicArgs, out *DynamicArgs, s conversion.Scope) error {
 	out.PolicyConfigPath = in.PolicyConfigPath
+	out.PolicyName = in.PolicyName
+	out.EnableNodeLoad = in.EnableNodeLoad
+	if err := v1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.ClockSkewTolerance, &out.ClockSkewTolerance, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.LegacyTimestamps, &out.LegacyTimestamps, s); err != nil {
+		return err
+	}
+	out.ScoreNormalization = ScoreNormalizationStrategy(in.ScoreNormalization)
+	out.EstimatePodUsage = in.EstimatePodUsage
+	out.ShadowMode = in.ShadowMode
+	out.ShadowModeEvents = in.ShadowModeEvents
+	if err := v1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.ThresholdRelaxationDelay, &out.ThresholdRelaxationDelay, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_float64_To_Pointer_float64(&in.HotValueWeight, &out.HotValueWeight, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -65,6 +102,8 @@ func Convert_config_DynamicArgs_To_v1beta2_DynamicArgs(in *config.DynamicArgs, o
 
 func autoConvert_v1beta2_NodeResourceTopologyMatchArgs_To_config_NodeResourceTopologyMatchArgs(in *NodeResourceTopologyMatchArgs, out *config.NodeResourceTopologyMatchArgs, s conversion.Scope) error {
 	out.TopologyAwareResources = *(*[]string)(unsafe.Pointer(&in.TopologyAwareResources))
+	out.PolicyConfigPath = in.PolicyConfigPath
+	out.PolicyName = in.PolicyName
 	return nil
 }
 
@@ -75,6 +114,8 @@ func Convert_v1beta2_NodeResourceTopologyMatchArgs_To_config_NodeResourceTopolog
 
 func autoConvert_config_NodeResourceTopologyMatchArgs_To_v1beta2_NodeResourceTopologyMatchArgs(in *config.NodeResourceTopologyMatchArgs, out *NodeResourceTopologyMatchArgs, s conversion.Scope) error {
 	out.TopologyAwareResources = *(*[]string)(unsafe.Pointer(&in.TopologyAwareResources))
+	out.PolicyConfigPath = in.PolicyConfigPath
+	out.PolicyName = in.PolicyName
 	return nil
 }
 
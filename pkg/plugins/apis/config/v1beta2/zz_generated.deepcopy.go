@@ -6,6 +6,7 @@
 package v1beta2
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -13,6 +14,26 @@ import (
 func (in *DynamicArgs) DeepCopyInto(out *DynamicArgs) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
+	if in.ClockSkewTolerance != nil {
+		in, out := &in.ClockSkewTolerance, &out.ClockSkewTolerance
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LegacyTimestamps != nil {
+		in, out := &in.LegacyTimestamps, &out.LegacyTimestamps
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ThresholdRelaxationDelay != nil {
+		in, out := &in.ThresholdRelaxationDelay, &out.ThresholdRelaxationDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HotValueWeight != nil {
+		in, out := &in.HotValueWeight, &out.HotValueWeight
+		*out = new(float64)
+		**out = **in
+	}
 	return
 }
 
@@ -48,6 +48,24 @@ func autoConvert_v1beta3_DynamicArgs_To_config_DynamicArgs(in *DynamicArgs, out
 	if err := v1.Convert_Pointer_string_To_string(&in.PolicyConfigPath, &out.PolicyConfigPath, s); err != nil {
 		return err
 	}
+	out.PolicyName = in.PolicyName
+	out.EnableNodeLoad = in.EnableNodeLoad
+	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.ClockSkewTolerance, &out.ClockSkewTolerance, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_bool_To_bool(&in.LegacyTimestamps, &out.LegacyTimestamps, s); err != nil {
+		return err
+	}
+	out.ScoreNormalization = config.ScoreNormalizationStrategy(in.ScoreNormalization)
+	out.EstimatePodUsage = in.EstimatePodUsage
+	out.ShadowMode = in.ShadowMode
+	out.ShadowModeEvents = in.ShadowModeEvents
+	if err := v1.Convert_Pointer_v1_Duration_To_v1_Duration(&in.ThresholdRelaxationDelay, &out.ThresholdRelaxationDelay, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_Pointer_float64_To_float64(&in.HotValueWeight, &out.HotValueWeight, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -60,6 +78,24 @@ func autoConvert_config_DynamicArgs_To_v1beta3_DynamicArgs(in *config.DynamicArg
 	if err := v1.Convert_string_To_Pointer_string(&in.PolicyConfigPath, &out.PolicyConfigPath, s); err != nil {
 		return err
 	}
+	out.PolicyName = in.PolicyName
+	out.EnableNodeLoad = in.EnableNodeLoad
+	if err := v1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.ClockSkewTolerance, &out.ClockSkewTolerance, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_bool_To_Pointer_bool(&in.LegacyTimestamps, &out.LegacyTimestamps, s); err != nil {
+		return err
+	}
+	out.ScoreNormalization = ScoreNormalizationStrategy(in.ScoreNormalization)
+	out.EstimatePodUsage = in.EstimatePodUsage
+	out.ShadowMode = in.ShadowMode
+	out.ShadowModeEvents = in.ShadowModeEvents
+	if err := v1.Convert_v1_Duration_To_Pointer_v1_Duration(&in.ThresholdRelaxationDelay, &out.ThresholdRelaxationDelay, s); err != nil {
+		return err
+	}
+	if err := v1.Convert_float64_To_Pointer_float64(&in.HotValueWeight, &out.HotValueWeight, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -70,6 +106,8 @@ func Convert_config_DynamicArgs_To_v1beta3_DynamicArgs(in *config.DynamicArgs, o
 
 func autoConvert_v1beta3_NodeResourceTopologyMatchArgs_To_config_NodeResourceTopologyMatchArgs(in *NodeResourceTopologyMatchArgs, out *config.NodeResourceTopologyMatchArgs, s conversion.Scope) error {
 	out.TopologyAwareResources = *(*[]string)(unsafe.Pointer(&in.TopologyAwareResources))
+	out.PolicyConfigPath = in.PolicyConfigPath
+	out.PolicyName = in.PolicyName
 	return nil
 }
 
@@ -80,6 +118,8 @@ func Convert_v1beta3_NodeResourceTopologyMatchArgs_To_config_NodeResourceTopolog
 
 func autoConvert_config_NodeResourceTopologyMatchArgs_To_v1beta3_NodeResourceTopologyMatchArgs(in *config.NodeResourceTopologyMatchArgs, out *NodeResourceTopologyMatchArgs, s conversion.Scope) error {
 	out.TopologyAwareResources = *(*[]string)(unsafe.Pointer(&in.TopologyAwareResources))
+	out.PolicyConfigPath = in.PolicyConfigPath
+	out.PolicyName = in.PolicyName
 	return nil
 }
 
@@ -6,6 +6,7 @@
 package v1beta3
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -18,6 +19,26 @@ func (in *DynamicArgs) DeepCopyInto(out *DynamicArgs) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ClockSkewTolerance != nil {
+		in, out := &in.ClockSkewTolerance, &out.ClockSkewTolerance
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LegacyTimestamps != nil {
+		in, out := &in.LegacyTimestamps, &out.LegacyTimestamps
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ThresholdRelaxationDelay != nil {
+		in, out := &in.ThresholdRelaxationDelay, &out.ThresholdRelaxationDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HotValueWeight != nil {
+		in, out := &in.HotValueWeight, &out.HotValueWeight
+		*out = new(float64)
+		**out = **in
+	}
 	return
 }
 
@@ -13,6 +13,8 @@ import (
 func (in *DynamicArgs) DeepCopyInto(out *DynamicArgs) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
+	out.ClockSkewTolerance = in.ClockSkewTolerance
+	out.ThresholdRelaxationDelay = in.ThresholdRelaxationDelay
 	return
 }
 
@@ -0,0 +1,5 @@
+// +k8s:deepcopy-gen=package,register
+// +groupName=nodeload.crane.io
+// +groupGoName=NodeLoad
+
+package v1alpha1 // import "github.com/gocrane/crane-scheduler/pkg/plugins/apis/nodeload/v1alpha1"
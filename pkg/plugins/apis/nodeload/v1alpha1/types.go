@@ -0,0 +1,57 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LoadAnnotationKey is the key of node annotation which stores the load of node in JSON
+// encoded LoadAnnotation, as an alternative to NodeLoad object.
+const LoadAnnotationKey = "nodeload.crane.io/load"
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope="Cluster",shortName=nl
+
+// NodeLoad is the real load of a node written by node annotator, which has the same name
+// as the node and is owned by it.
+type NodeLoad struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Metrics are the usage of node keyed by metric name in sync policy.
+	// +optional
+	Metrics map[string]MetricValue `json:"metrics,omitempty"`
+	// HotValue is the scheduling frequency of node in recent times.
+	// +optional
+	HotValue *MetricValue `json:"hotValue,omitempty"`
+}
+
+// LoadAnnotation is the content of the node annotation keyed by LoadAnnotationKey, which
+// has the same fields as NodeLoad.
+type LoadAnnotation struct {
+	// Metrics are the usage of node keyed by metric name in sync policy.
+	// +optional
+	Metrics map[string]MetricValue `json:"metrics,omitempty"`
+	// HotValue is the scheduling frequency of node in recent times.
+	// +optional
+	HotValue *MetricValue `json:"hotValue,omitempty"`
+}
+
+// MetricValue is the value of a metric with the time it was collected.
+type MetricValue struct {
+	Value float64 `json:"value"`
+	// Timestamp is serialized in RFC3339 format.
+	Timestamp metav1.Time `json:"timestamp"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeLoadList is a list of NodeLoad objects.
+type NodeLoadList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []NodeLoad `json:"items"`
+}
@@ -6,14 +6,38 @@
 package policy
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BalancePolicy) DeepCopyInto(out *BalancePolicy) {
+	*out = *in
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BalancePolicy.
+func (in *BalancePolicy) DeepCopy() *BalancePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BalancePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DynamicSchedulerPolicy) DeepCopyInto(out *DynamicSchedulerPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -35,10 +59,85 @@ func (in *DynamicSchedulerPolicy) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynamicSchedulerPolicyList) DeepCopyInto(out *DynamicSchedulerPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DynamicSchedulerPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynamicSchedulerPolicyList.
+func (in *DynamicSchedulerPolicyList) DeepCopy() *DynamicSchedulerPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(DynamicSchedulerPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DynamicSchedulerPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExemptionRules) DeepCopyInto(out *ExemptionRules) {
+	*out = *in
+	if in.PriorityClassNames != nil {
+		in, out := &in.PriorityClassNames, &out.PriorityClassNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinPriority != nil {
+		in, out := &in.MinPriority, &out.MinPriority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OwnerKinds != nil {
+		in, out := &in.OwnerKinds, &out.OwnerKinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExemptionRules.
+func (in *ExemptionRules) DeepCopy() *ExemptionRules {
+	if in == nil {
+		return nil
+	}
+	out := new(ExemptionRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HotValuePolicy) DeepCopyInto(out *HotValuePolicy) {
 	*out = *in
-	in.TimeRange.DeepCopyInto(&out.TimeRange)
+	out.TimeRange = in.TimeRange
+	out.HalfLife = in.HalfLife
 	return
 }
 
@@ -53,32 +152,163 @@ func (in *HotValuePolicy) DeepCopy() *HotValuePolicy {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolicySpec) DeepCopyInto(out *PolicySpec) {
+func (in *MetricSyncStatus) DeepCopyInto(out *MetricSyncStatus) {
 	*out = *in
+	in.LastSyncTime.DeepCopyInto(&out.LastSyncTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricSyncStatus.
+func (in *MetricSyncStatus) DeepCopy() *MetricSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolPolicy) DeepCopyInto(out *NodePoolPolicy) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SyncPeriod != nil {
 		in, out := &in.SyncPeriod, &out.SyncPeriod
 		*out = make([]SyncPolicy, len(*in))
+		copy(*out, *in)
+	}
+	if in.Predicate != nil {
+		in, out := &in.Predicate, &out.Predicate
+		*out = make([]PredicatePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = make([]PriorityPolicy, len(*in))
+		copy(*out, *in)
+	}
+	if in.Balance != nil {
+		in, out := &in.Balance, &out.Balance
+		*out = make([]BalancePolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HotValue != nil {
+		in, out := &in.HotValue, &out.HotValue
+		*out = make([]HotValuePolicy, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolPolicy.
+func (in *NodePoolPolicy) DeepCopy() *NodePoolPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyProfile) DeepCopyInto(out *PolicyProfile) {
+	*out = *in
+	if in.PriorityClassNames != nil {
+		in, out := &in.PriorityClassNames, &out.PriorityClassNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Predicate != nil {
 		in, out := &in.Predicate, &out.Predicate
 		*out = make([]PredicatePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = make([]PriorityPolicy, len(*in))
 		copy(*out, *in)
 	}
+	if in.Balance != nil {
+		in, out := &in.Balance, &out.Balance
+		*out = make([]BalancePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyProfile.
+func (in *PolicyProfile) DeepCopy() *PolicyProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicySpec) DeepCopyInto(out *PolicySpec) {
+	*out = *in
+	if in.SyncPeriod != nil {
+		in, out := &in.SyncPeriod, &out.SyncPeriod
+		*out = make([]SyncPolicy, len(*in))
+		copy(*out, *in)
+	}
+	if in.Predicate != nil {
+		in, out := &in.Predicate, &out.Predicate
+		*out = make([]PredicatePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Priority != nil {
 		in, out := &in.Priority, &out.Priority
 		*out = make([]PriorityPolicy, len(*in))
 		copy(*out, *in)
 	}
+	if in.Balance != nil {
+		in, out := &in.Balance, &out.Balance
+		*out = make([]BalancePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.HotValue != nil {
 		in, out := &in.HotValue, &out.HotValue
 		*out = make([]HotValuePolicy, len(*in))
+		copy(*out, *in)
+	}
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]PolicyProfile, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = make([]NodePoolPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Exemptions != nil {
+		in, out := &in.Exemptions, &out.Exemptions
+		*out = new(ExemptionRules)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -92,9 +322,37 @@ func (in *PolicySpec) DeepCopy() *PolicySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyStatus) DeepCopyInto(out *PolicyStatus) {
+	*out = *in
+	if in.SyncStatus != nil {
+		in, out := &in.SyncStatus, &out.SyncStatus
+		*out = make([]MetricSyncStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyStatus.
+func (in *PolicyStatus) DeepCopy() *PolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PredicatePolicy) DeepCopyInto(out *PredicatePolicy) {
 	*out = *in
+	if in.Relaxation != nil {
+		in, out := &in.Relaxation, &out.Relaxation
+		*out = new(ThresholdRelaxation)
+		**out = **in
+	}
 	return
 }
 
@@ -127,7 +385,7 @@ func (in *PriorityPolicy) DeepCopy() *PriorityPolicy {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncPolicy) DeepCopyInto(out *SyncPolicy) {
 	*out = *in
-	in.Period.DeepCopyInto(&out.Period)
+	out.Period = in.Period
 	return
 }
 
@@ -140,3 +398,19 @@ func (in *SyncPolicy) DeepCopy() *SyncPolicy {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ThresholdRelaxation) DeepCopyInto(out *ThresholdRelaxation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ThresholdRelaxation.
+func (in *ThresholdRelaxation) DeepCopy() *ThresholdRelaxation {
+	if in == nil {
+		return nil
+	}
+	out := new(ThresholdRelaxation)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -1,3 +1,4 @@
 // +k8s:deepcopy-gen=package
+// +groupName=scheduler.policy.crane.io
 
 package policy // import "crane.io/crane-scheduler/pkg/plugins/apis/policy"
@@ -0,0 +1,56 @@
+package helper
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+// IsPodExempted returns true if pod matches any of the exemption rules, which may be nil.
+func IsPodExempted(pod *v1.Pod, rules *policy.ExemptionRules) bool {
+	if rules == nil {
+		return false
+	}
+
+	if containsString(rules.PriorityClassNames, pod.Spec.PriorityClassName) {
+		return true
+	}
+
+	if rules.MinPriority != nil && pod.Spec.Priority != nil && *pod.Spec.Priority >= *rules.MinPriority {
+		return true
+	}
+
+	if containsString(rules.Namespaces, pod.Namespace) {
+		return true
+	}
+
+	if rules.PodSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(rules.PodSelector)
+		if err != nil {
+			klog.Warningf("Invalid pod selector of exemption rules: %v", err)
+		} else if selector.Matches(labels.Set(pod.Labels)) {
+			return true
+		}
+	}
+
+	for _, ownerRef := range pod.OwnerReferences {
+		if containsString(rules.OwnerKinds, ownerRef.Kind) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
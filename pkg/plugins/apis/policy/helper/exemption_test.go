@@ -0,0 +1,86 @@
+package helper
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+func TestIsPodExempted(t *testing.T) {
+	minPriority := int32(1000000)
+	highPriority, lowPriority := int32(2000000000), int32(0)
+
+	rules := &policy.ExemptionRules{
+		PriorityClassNames: []string{"system-node-critical"},
+		MinPriority:        &minPriority,
+		Namespaces:         []string{"kube-system"},
+		PodSelector:        &metav1.LabelSelector{MatchLabels: map[string]string{"critical": "true"}},
+		OwnerKinds:         []string{"Node"},
+	}
+
+	if IsPodExempted(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system"}}, nil) {
+		t.Errorf("IsPodExempted() = true without exemption rules, want false")
+	}
+
+	tests := []struct {
+		name string
+		pod  *v1.Pod
+		want bool
+	}{
+		{
+			name: "priority class name",
+			pod:  &v1.Pod{Spec: v1.PodSpec{PriorityClassName: "system-node-critical"}},
+			want: true,
+		},
+		{
+			name: "priority above minimum",
+			pod:  &v1.Pod{Spec: v1.PodSpec{Priority: &highPriority}},
+			want: true,
+		},
+		{
+			name: "priority below minimum",
+			pod:  &v1.Pod{Spec: v1.PodSpec{Priority: &lowPriority}},
+			want: false,
+		},
+		{
+			name: "namespace",
+			pod:  &v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system"}},
+			want: true,
+		},
+		{
+			name: "pod labels",
+			pod:  &v1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"critical": "true"}}},
+			want: true,
+		},
+		{
+			name: "static pod",
+			pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+				OwnerReferences: []metav1.OwnerReference{{Kind: "Node", Name: "node1"}},
+			}},
+			want: true,
+		},
+		{
+			name: "no rule matched",
+			pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       "default",
+					Labels:          map[string]string{"critical": "false"},
+					OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web"}},
+				},
+				Spec: v1.PodSpec{PriorityClassName: "high-priority", Priority: &lowPriority},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsPodExempted(tt.pod, rules); got != tt.want {
+				t.Errorf("IsPodExempted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
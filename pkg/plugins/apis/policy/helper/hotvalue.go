@@ -0,0 +1,44 @@
+package helper
+
+import (
+	"math"
+	"time"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+// HotValueBindingWeight returns how much a binding of age counts for the hot value of hotValuePolicy
+// according to its model, which is 0 out of its time range.
+func HotValueBindingWeight(hotValuePolicy policy.HotValuePolicy, age time.Duration) float64 {
+	timeRange := hotValuePolicy.TimeRange.Duration
+	if age >= timeRange {
+		return 0
+	}
+	if age < 0 {
+		age = 0
+	}
+
+	switch hotValuePolicy.Model {
+	case policy.HotValueModelLinear:
+		return 1 - float64(age)/float64(timeRange)
+	case policy.HotValueModelExponentialDecay:
+		return math.Exp2(-float64(age) / float64(hotValuePolicy.HalfLife.Duration))
+	default:
+		return 1
+	}
+}
+
+// HotValue returns the hot value of hotValuePolicy given the total weight of bindings. The Step
+// model adds 1 for every count bindings, while the others add weight/count.
+func HotValue(hotValuePolicy policy.HotValuePolicy, weight float64) float64 {
+	if hotValuePolicy.Count <= 0 {
+		return 0
+	}
+
+	switch hotValuePolicy.Model {
+	case policy.HotValueModelLinear, policy.HotValueModelExponentialDecay:
+		return weight / float64(hotValuePolicy.Count)
+	default:
+		return math.Floor(weight / float64(hotValuePolicy.Count))
+	}
+}
@@ -0,0 +1,48 @@
+package helper
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+func TestHotValueModels(t *testing.T) {
+	fiveMinutes := metav1.Duration{Duration: 5 * time.Minute}
+	step := policy.HotValuePolicy{TimeRange: fiveMinutes, Count: 2}
+	linear := policy.HotValuePolicy{TimeRange: fiveMinutes, Count: 2, Model: policy.HotValueModelLinear}
+	decay := policy.HotValuePolicy{TimeRange: fiveMinutes, Count: 2, Model: policy.HotValueModelExponentialDecay,
+		HalfLife: metav1.Duration{Duration: time.Minute}}
+
+	tests := []struct {
+		name   string
+		policy policy.HotValuePolicy
+		ages   []time.Duration
+		want   float64
+	}{
+		{name: "step below count", policy: step, ages: []time.Duration{0}, want: 0},
+		{name: "step reaching count", policy: step, ages: []time.Duration{0, 4 * time.Minute, 4 * time.Minute}, want: 1},
+		{name: "step out of time range", policy: step, ages: []time.Duration{0, 5 * time.Minute}, want: 0},
+		{name: "linear", policy: linear, ages: []time.Duration{0, 150 * time.Second}, want: 1.5 / 2},
+		{name: "linear in future", policy: linear, ages: []time.Duration{-time.Minute}, want: 1. / 2},
+		{name: "exponential decay", policy: decay, ages: []time.Duration{0, time.Minute, 2 * time.Minute}, want: 1.75 / 2},
+		{name: "exponential decay out of time range", policy: decay, ages: []time.Duration{6 * time.Minute}, want: 0},
+		{name: "zero count", policy: policy.HotValuePolicy{TimeRange: fiveMinutes}, ages: []time.Duration{0}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var weight float64
+			for _, age := range tt.ages {
+				weight += HotValueBindingWeight(tt.policy, age)
+			}
+
+			if got := HotValue(tt.policy, weight); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("HotValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package helper
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+// NodePools matches nodes against the node pools of a policy, whose label selectors are
+// converted once.
+type NodePools struct {
+	pools     []policy.NodePoolPolicy
+	selectors []labels.Selector
+}
+
+// NewNodePools returns a NodePools object. A node pool without selector or with invalid one
+// matches no node.
+func NewNodePools(pools []policy.NodePoolPolicy) *NodePools {
+	selectors := make([]labels.Selector, len(pools))
+	for i, pool := range pools {
+		selector, err := metav1.LabelSelectorAsSelector(pool.NodeSelector)
+		if err != nil {
+			klog.Warningf("Invalid node selector of node pool %s: %v", pool.Name, err)
+			selector = labels.Nothing()
+		}
+		selectors[i] = selector
+	}
+
+	return &NodePools{pools: pools, selectors: selectors}
+}
+
+// Match returns the first node pool whose selector matches the labels of node, or nil if
+// the node belongs to no pool.
+func (p *NodePools) Match(node *v1.Node) *policy.NodePoolPolicy {
+	for i, selector := range p.selectors {
+		if selector.Matches(labels.Set(node.Labels)) {
+			return &p.pools[i]
+		}
+	}
+
+	return nil
+}
+
+// ApplyNodePool overrides the sync, predicate, priority, balance and hot value policies of spec
+// with those of pool, which may be nil.
+func ApplyNodePool(spec policy.PolicySpec, pool *policy.NodePoolPolicy) policy.PolicySpec {
+	if pool == nil {
+		return spec
+	}
+
+	if len(pool.SyncPeriod) > 0 {
+		spec.SyncPeriod = pool.SyncPeriod
+	}
+	if len(pool.Predicate) > 0 {
+		spec.Predicate = pool.Predicate
+	}
+	if len(pool.Priority) > 0 {
+		spec.Priority = pool.Priority
+	}
+	if len(pool.Balance) > 0 {
+		spec.Balance = pool.Balance
+	}
+	if len(pool.HotValue) > 0 {
+		spec.HotValue = pool.HotValue
+	}
+
+	return spec
+}
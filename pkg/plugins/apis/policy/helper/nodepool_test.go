@@ -0,0 +1,38 @@
+package helper
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+func TestApplyNodePool(t *testing.T) {
+	spec := policy.PolicySpec{
+		SyncPeriod: []policy.SyncPolicy{{Name: "cpu_usage_avg_5m"}, {Name: "mem_usage_avg_5m"}},
+		Predicate:  []policy.PredicatePolicy{{Name: "cpu_usage_avg_5m", MaxLimitPecent: 0.65}},
+		Priority:   []policy.PriorityPolicy{{Name: "cpu_usage_avg_5m", Weight: 0.2}},
+		Balance:    []policy.BalancePolicy{{Name: "cpu-mem", Metrics: []string{"cpu_usage_avg_5m", "mem_usage_avg_5m"}, Weight: 1}},
+		HotValue:   []policy.HotValuePolicy{{TimeRange: metav1.Duration{Duration: 5 * time.Minute}, Count: 5}},
+	}
+
+	if got := ApplyNodePool(spec, nil); got.Balance[0].Weight != 1 || got.HotValue[0].Count != 5 {
+		t.Errorf("ApplyNodePool() without node pool = %v, want spec", got)
+	}
+
+	// the policies not set by node pool are inherited from spec.
+	got := ApplyNodePool(spec, &policy.NodePoolPolicy{
+		Name:      "gpu",
+		Predicate: []policy.PredicatePolicy{{Name: "cpu_usage_avg_5m", MaxLimitPecent: 0.8}},
+		Balance:   []policy.BalancePolicy{{Name: "cpu-mem", Metrics: []string{"cpu_usage_avg_5m", "mem_usage_avg_5m"}, Weight: 2}},
+		HotValue:  []policy.HotValuePolicy{{TimeRange: metav1.Duration{Duration: time.Minute}, Count: 2}},
+	})
+	if got.Predicate[0].MaxLimitPecent != 0.8 || got.Priority[0].Weight != 0.2 {
+		t.Errorf("ApplyNodePool() predicate and priority = %v, %v, want 0.8, 0.2", got.Predicate[0].MaxLimitPecent, got.Priority[0].Weight)
+	}
+	if got.Balance[0].Weight != 2 || got.HotValue[0].Count != 2 {
+		t.Errorf("ApplyNodePool() balance and hot value = %v, %v, want 2, 2", got.Balance[0].Weight, got.HotValue[0].Count)
+	}
+}
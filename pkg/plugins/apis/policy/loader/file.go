@@ -1,4 +1,6 @@
-package dynamic
+// Package loader loads DynamicSchedulerPolicy from the policy file or the object served by
+// apiserver, and watches its changes, for the components sharing the policy.
+package loader
 
 import (
 	"fmt"
@@ -6,6 +8,7 @@ import (
 
 	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
 	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/scheme"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/validation"
 )
 
 func LoadPolicyFromFile(file string) (*policy.DynamicSchedulerPolicy, error) {
@@ -26,6 +29,9 @@ func loadPolicy(data []byte) (*policy.DynamicSchedulerPolicy, error) {
 
 	if policyObj, ok := obj.(*policy.DynamicSchedulerPolicy); ok {
 		policyObj.TypeMeta.APIVersion = gvk.GroupVersion().String()
+		if err := validation.ValidateDynamicSchedulerPolicy(policyObj).ToAggregate(); err != nil {
+			return nil, fmt.Errorf("invalid DynamicSchedulerPolicy: %v", err)
+		}
 		return policyObj, nil
 	}
 
@@ -0,0 +1,82 @@
+package loader
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	policyinformers "github.com/gocrane/crane-scheduler/pkg/generated/informers/externalversions/policy/v1alpha1"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/scheme"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/v1alpha1"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/validation"
+)
+
+// ConvertPolicyObject converts the DynamicSchedulerPolicy object served by apiserver to
+// the internal version, and validates it.
+func ConvertPolicyObject(obj *v1alpha1.DynamicSchedulerPolicy) (*policy.DynamicSchedulerPolicy, error) {
+	versioned := obj.DeepCopy()
+	scheme.Scheme.Default(versioned)
+
+	policyObj := &policy.DynamicSchedulerPolicy{}
+	if err := scheme.Scheme.Convert(versioned, policyObj, nil); err != nil {
+		return nil, fmt.Errorf("failed to convert DynamicSchedulerPolicy %s: %v", obj.Name, err)
+	}
+	policyObj.TypeMeta.APIVersion = v1alpha1.SchemeGroupVersion.String()
+
+	if err := validation.ValidateDynamicSchedulerPolicy(policyObj).ToAggregate(); err != nil {
+		return nil, fmt.Errorf("invalid DynamicSchedulerPolicy %s: %v", obj.Name, err)
+	}
+
+	return policyObj, nil
+}
+
+// GetPolicyObject returns the DynamicSchedulerPolicy object with the given name in the
+// informer cache, converted to the internal version. Unlike the handlers added by
+// WatchPolicyObject, which are notified asynchronously, it observes the object as soon as
+// the informer has been synced.
+func GetPolicyObject(informer policyinformers.DynamicSchedulerPolicyInformer, name string) (*policy.DynamicSchedulerPolicy, error) {
+	policyObj, err := informer.Lister().Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DynamicSchedulerPolicy %s: %v", name, err)
+	}
+
+	return ConvertPolicyObject(policyObj)
+}
+
+// WatchPolicyObject watches the DynamicSchedulerPolicy object with the given name, and
+// notifies the handler once a changed and valid policy has been observed.
+func WatchPolicyObject(informer policyinformers.DynamicSchedulerPolicyInformer, name string, handler PolicyUpdateHandler) {
+	var current *policy.DynamicSchedulerPolicy
+
+	update := func(obj interface{}) {
+		policyObj, ok := obj.(*v1alpha1.DynamicSchedulerPolicy)
+		if !ok || policyObj.Name != name {
+			return
+		}
+
+		newPolicy, err := ConvertPolicyObject(policyObj)
+		if err != nil {
+			klog.Errorf("Failed to apply DynamicSchedulerPolicy %s, keep using the current one: %v", name, err)
+			return
+		}
+
+		if current != nil && current.Generation == newPolicy.Generation && reflect.DeepEqual(current.Spec, newPolicy.Spec) {
+			return
+		}
+
+		klog.Infof("DynamicSchedulerPolicy %s changed, apply the generation %d", name, newPolicy.Generation)
+
+		current = newPolicy
+		handler(newPolicy)
+	}
+
+	informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: update,
+		UpdateFunc: func(_, newObj interface{}) {
+			update(newObj)
+		},
+	})
+}
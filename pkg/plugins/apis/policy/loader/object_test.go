@@ -0,0 +1,137 @@
+package loader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	cranefake "github.com/gocrane/crane-scheduler/pkg/generated/clientset/versioned/fake"
+	craneinformers "github.com/gocrane/crane-scheduler/pkg/generated/informers/externalversions"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/v1alpha1"
+)
+
+func newPolicyObject(name string, generation int64, maxLimitPercent float64) *v1alpha1.DynamicSchedulerPolicy {
+	return &v1alpha1.DynamicSchedulerPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Generation: generation},
+		Spec: v1alpha1.PolicySpec{
+			SyncPeriod: []v1alpha1.SyncPolicy{
+				{Name: "cpu_usage_avg_5m", Period: metav1.Duration{Duration: 3 * time.Minute}},
+			},
+			Predicate: []v1alpha1.PredicatePolicy{
+				{Name: "cpu_usage_avg_5m", MaxLimitPecent: maxLimitPercent},
+			},
+			Priority: []v1alpha1.PriorityPolicy{
+				{Name: "cpu_usage_avg_5m", Weight: 0.2},
+			},
+		},
+	}
+}
+
+func TestConvertPolicyObject(t *testing.T) {
+	got, err := ConvertPolicyObject(newPolicyObject("default", 2, 0.65))
+	if err != nil {
+		t.Fatalf("ConvertPolicyObject() error = %v", err)
+	}
+
+	if got.Name != "default" || got.Generation != 2 {
+		t.Errorf("ObjectMeta = %+v, want name default and generation 2", got.ObjectMeta)
+	}
+	if got.APIVersion != v1alpha1.SchemeGroupVersion.String() {
+		t.Errorf("APIVersion = %q, want %q", got.APIVersion, v1alpha1.SchemeGroupVersion.String())
+	}
+	if len(got.Spec.Predicate) != 1 || got.Spec.Predicate[0].MaxLimitPecent != 0.65 {
+		t.Errorf("Predicate = %+v, want cpu_usage_avg_5m with 0.65", got.Spec.Predicate)
+	}
+
+	if _, err := ConvertPolicyObject(newPolicyObject("default", 2, 1.5)); err == nil {
+		t.Errorf("ConvertPolicyObject() of invalid policy succeeded, want error")
+	}
+}
+
+func TestGetPolicyObject(t *testing.T) {
+	craneClient := cranefake.NewSimpleClientset(
+		newPolicyObject("default", 1, 0.65),
+		newPolicyObject("invalid", 1, 1.5),
+	)
+	informerFactory := craneinformers.NewSharedInformerFactory(craneClient, 0)
+	informer := informerFactory.Scheduler().V1alpha1().DynamicSchedulerPolicies()
+	informer.Informer()
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	informerFactory.Start(stopCh)
+	informerFactory.WaitForCacheSync(stopCh)
+
+	p, err := GetPolicyObject(informer, "default")
+	if err != nil {
+		t.Fatalf("GetPolicyObject() error = %v", err)
+	}
+	if p.Name != "default" || p.Spec.Predicate[0].MaxLimitPecent != 0.65 {
+		t.Errorf("policy = %s with %v, want default with 0.65", p.Name, p.Spec.Predicate[0].MaxLimitPecent)
+	}
+
+	if _, err := GetPolicyObject(informer, "invalid"); err == nil {
+		t.Errorf("GetPolicyObject() of invalid policy succeeded, want error")
+	}
+	if _, err := GetPolicyObject(informer, "missing"); err == nil {
+		t.Errorf("GetPolicyObject() of missing policy succeeded, want error")
+	}
+}
+
+func TestWatchPolicyObject(t *testing.T) {
+	craneClient := cranefake.NewSimpleClientset(
+		newPolicyObject("default", 1, 0.65),
+		newPolicyObject("other", 1, 0.3),
+	)
+	informerFactory := craneinformers.NewSharedInformerFactory(craneClient, 0)
+
+	updates := make(chan *policy.DynamicSchedulerPolicy, 10)
+	WatchPolicyObject(informerFactory.Scheduler().V1alpha1().DynamicSchedulerPolicies(), "default", func(p *policy.DynamicSchedulerPolicy) {
+		updates <- p
+	})
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	informerFactory.Start(stopCh)
+	informerFactory.WaitForCacheSync(stopCh)
+
+	waitForUpdate := func() *policy.DynamicSchedulerPolicy {
+		select {
+		case p := <-updates:
+			return p
+		case <-time.After(wait.ForeverTestTimeout):
+			t.Fatalf("policy is not updated")
+			return nil
+		}
+	}
+
+	if p := waitForUpdate(); p.Name != "default" || p.Spec.Predicate[0].MaxLimitPecent != 0.65 {
+		t.Fatalf("initial policy = %s with %v, want default with 0.65", p.Name, p.Spec.Predicate[0].MaxLimitPecent)
+	}
+
+	policyClient := craneClient.SchedulerV1alpha1().DynamicSchedulerPolicies()
+	update := func(obj *v1alpha1.DynamicSchedulerPolicy) {
+		if _, err := policyClient.Update(context.TODO(), obj, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("failed to update DynamicSchedulerPolicy: %v", err)
+		}
+	}
+
+	// the invalid generation and the changes of other policies are ignored.
+	update(newPolicyObject("default", 2, 1.5))
+	update(newPolicyObject("other", 2, 0.4))
+	update(newPolicyObject("default", 3, 0.5))
+
+	if p := waitForUpdate(); p.Generation != 3 || p.Spec.Predicate[0].MaxLimitPecent != 0.5 {
+		t.Fatalf("updated policy = generation %d with %v, want generation 3 with 0.5", p.Generation, p.Spec.Predicate[0].MaxLimitPecent)
+	}
+
+	select {
+	case p := <-updates:
+		t.Errorf("unexpected policy update %s of generation %d", p.Name, p.Generation)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
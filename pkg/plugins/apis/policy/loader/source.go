@@ -0,0 +1,158 @@
+package loader
+
+import (
+	"sync"
+
+	"k8s.io/klog/v2"
+
+	craneclientset "github.com/gocrane/crane-scheduler/pkg/generated/clientset/versioned"
+	craneinformers "github.com/gocrane/crane-scheduler/pkg/generated/informers/externalversions"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+// policySource is a policy file or object watched once per process, whose updates are
+// dispatched to all the subscribers, such as the plugins of each scheduler profile.
+type policySource struct {
+	key    string
+	stopCh chan struct{}
+
+	lock     sync.Mutex
+	current  *policy.DynamicSchedulerPolicy
+	handlers map[int]PolicyUpdateHandler
+	nextID   int
+}
+
+var (
+	// sourcesLock guards sources, and serializes the creation of sources.
+	sourcesLock sync.Mutex
+	// sources are the policy sources subscribed, keyed by the file path or object name.
+	sources = map[string]*policySource{}
+)
+
+func newPolicySource(key string) *policySource {
+	return &policySource{
+		key:      key,
+		stopCh:   make(chan struct{}),
+		handlers: map[int]PolicyUpdateHandler{},
+	}
+}
+
+// dispatch notifies the subscribers of the changed policy p.
+func (s *policySource) dispatch(p *policy.DynamicSchedulerPolicy) {
+	s.lock.Lock()
+	s.current = p
+	handlers := make([]PolicyUpdateHandler, 0, len(s.handlers))
+	for _, handler := range s.handlers {
+		handlers = append(handlers, handler)
+	}
+	s.lock.Unlock()
+
+	for _, handler := range handlers {
+		handler(p)
+	}
+}
+
+// initCurrent sets the current policy unless a newer one has been dispatched.
+func (s *policySource) initCurrent(p *policy.DynamicSchedulerPolicy) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.current == nil {
+		s.current = p
+	}
+}
+
+// subscribe adds handler and returns the current policy along with the function cancelling
+// the subscription. The source is stopped once all subscriptions are cancelled. It must be
+// called with sourcesLock held.
+func (s *policySource) subscribe(handler PolicyUpdateHandler) (*policy.DynamicSchedulerPolicy, func()) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	id := s.nextID
+	s.nextID++
+	s.handlers[id] = handler
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			sourcesLock.Lock()
+			defer sourcesLock.Unlock()
+
+			s.lock.Lock()
+			delete(s.handlers, id)
+			empty := len(s.handlers) == 0
+			s.lock.Unlock()
+
+			if empty {
+				klog.V(4).InfoS("Stop watching scheduler policy", "source", s.key)
+				delete(sources, s.key)
+				close(s.stopCh)
+			}
+		})
+	}
+
+	return s.current, cancel
+}
+
+// SubscribePolicyFile loads the policy file, and notifies the handler once a changed and
+// valid policy has been loaded. The file is watched once however many times it is
+// subscribed, until all the subscriptions are cancelled.
+func SubscribePolicyFile(file string, handler PolicyUpdateHandler) (*policy.DynamicSchedulerPolicy, func(), error) {
+	sourcesLock.Lock()
+	defer sourcesLock.Unlock()
+
+	key := "file:" + file
+	s, ok := sources[key]
+	if !ok {
+		current, err := LoadPolicyFromFile(file)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		klog.V(4).InfoS("Start watching scheduler policy", "source", key)
+		s = newPolicySource(key)
+		s.current = current
+		go NewPolicyWatcher(file, current, s.dispatch).Run(s.stopCh)
+		sources[key] = s
+	}
+
+	current, cancel := s.subscribe(handler)
+	return current, cancel, nil
+}
+
+// SubscribePolicyObject gets the DynamicSchedulerPolicy object with the given name, and
+// notifies the handler once a changed and valid policy has been observed. The object is
+// watched once however many times it is subscribed, until all the subscriptions are
+// cancelled, and client is only used by the first subscription.
+func SubscribePolicyObject(client craneclientset.Interface, name string, handler PolicyUpdateHandler) (*policy.DynamicSchedulerPolicy, func(), error) {
+	sourcesLock.Lock()
+	defer sourcesLock.Unlock()
+
+	key := "object:" + name
+	s, ok := sources[key]
+	if !ok {
+		klog.V(4).InfoS("Start watching scheduler policy", "source", key)
+		s = newPolicySource(key)
+
+		informerFactory := craneinformers.NewSharedInformerFactory(client, 0)
+		policyInformer := informerFactory.Scheduler().V1alpha1().DynamicSchedulerPolicies()
+		WatchPolicyObject(policyInformer, name, s.dispatch)
+
+		informerFactory.Start(s.stopCh)
+		informerFactory.WaitForCacheSync(s.stopCh)
+
+		// the policy watched may not be delivered to the handler yet when the cache has been
+		// synced, so it is read from the cache, unless a newer one has been dispatched.
+		current, err := GetPolicyObject(policyInformer, name)
+		if err != nil {
+			close(s.stopCh)
+			return nil, nil, err
+		}
+		s.initCurrent(current)
+		sources[key] = s
+	}
+
+	current, cancel := s.subscribe(handler)
+	return current, cancel, nil
+}
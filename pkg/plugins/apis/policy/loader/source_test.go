@@ -0,0 +1,142 @@
+package loader
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	cranefake "github.com/gocrane/crane-scheduler/pkg/generated/clientset/versioned/fake"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+func isStopped(s *policySource) bool {
+	select {
+	case <-s.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestSubscribePolicyFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	writeTestPolicy(t, file, "0.65")
+
+	// each profile subscribes to the policy file once.
+	updates := []chan *policy.DynamicSchedulerPolicy{
+		make(chan *policy.DynamicSchedulerPolicy, 10),
+		make(chan *policy.DynamicSchedulerPolicy, 10),
+	}
+	var cancels []func()
+	for _, ch := range updates {
+		ch := ch
+		current, cancel, err := SubscribePolicyFile(file, func(p *policy.DynamicSchedulerPolicy) {
+			ch <- p
+		})
+		if err != nil {
+			t.Fatalf("SubscribePolicyFile() error = %v", err)
+		}
+		if current.Spec.Predicate[0].MaxLimitPecent != 0.65 {
+			t.Errorf("MaxLimitPecent = %v, want 0.65", current.Spec.Predicate[0].MaxLimitPecent)
+		}
+		cancels = append(cancels, cancel)
+	}
+
+	sourcesLock.Lock()
+	s, ok := sources["file:"+file]
+	numSources := len(sources)
+	sourcesLock.Unlock()
+	if !ok || numSources != 1 || len(s.handlers) != 2 {
+		t.Fatalf("%d sources with %d handlers for 2 profiles, want 1 source with 2 handlers", numSources, len(s.handlers))
+	}
+
+	// the watch is set up asynchronously, so the file is written until it is reloaded.
+	var updated *policy.DynamicSchedulerPolicy
+	for deadline := time.Now().Add(10 * time.Second); updated == nil && time.Now().Before(deadline); {
+		writeTestPolicy(t, file, "0.5")
+		select {
+		case updated = <-updates[0]:
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	if updated == nil {
+		t.Fatalf("policy is not reloaded after the file changed")
+	}
+	select {
+	case p := <-updates[1]:
+		if p != updated {
+			t.Errorf("profiles are notified of different policies")
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("the other profile is not notified")
+	}
+
+	// the watcher keeps running until all the subscriptions are cancelled.
+	cancels[0]()
+	cancels[0]()
+	if isStopped(s) {
+		t.Fatalf("policy source is stopped with a subscription left")
+	}
+	cancels[1]()
+	if !isStopped(s) {
+		t.Errorf("policy source is not stopped after all subscriptions are cancelled")
+	}
+
+	sourcesLock.Lock()
+	defer sourcesLock.Unlock()
+	if len(sources) != 0 {
+		t.Errorf("sources = %v after all subscriptions are cancelled, want none", sources)
+	}
+}
+
+func TestSubscribePolicyFileInvalid(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	writeTestPolicy(t, file, "1.5")
+
+	if _, _, err := SubscribePolicyFile(file, func(*policy.DynamicSchedulerPolicy) {}); err == nil {
+		t.Errorf("SubscribePolicyFile() of invalid policy succeeded, want error")
+	}
+
+	sourcesLock.Lock()
+	defer sourcesLock.Unlock()
+	if len(sources) != 0 {
+		t.Errorf("sources = %v after failed subscription, want none", sources)
+	}
+}
+
+func TestSubscribePolicyObject(t *testing.T) {
+	craneClient := cranefake.NewSimpleClientset(newPolicyObject("default", 1, 0.65))
+
+	var cancels []func()
+	for i := 0; i < 2; i++ {
+		current, cancel, err := SubscribePolicyObject(craneClient, "default", func(*policy.DynamicSchedulerPolicy) {})
+		if err != nil {
+			t.Fatalf("SubscribePolicyObject() error = %v", err)
+		}
+		if current.Name != "default" || current.Spec.Predicate[0].MaxLimitPecent != 0.65 {
+			t.Errorf("policy = %s with %v, want default with 0.65", current.Name, current.Spec.Predicate[0].MaxLimitPecent)
+		}
+		cancels = append(cancels, cancel)
+	}
+
+	sourcesLock.Lock()
+	s, ok := sources["object:default"]
+	numSources := len(sources)
+	sourcesLock.Unlock()
+	if !ok || numSources != 1 {
+		t.Fatalf("%d sources for 2 profiles, want 1", numSources)
+	}
+
+	if _, _, err := SubscribePolicyObject(craneClient, "missing", func(*policy.DynamicSchedulerPolicy) {}); err == nil {
+		t.Errorf("SubscribePolicyObject() of missing policy succeeded, want error")
+	}
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+	if !isStopped(s) {
+		t.Errorf("policy source is not stopped after all subscriptions are cancelled")
+	}
+}
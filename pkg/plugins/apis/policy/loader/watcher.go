@@ -0,0 +1,92 @@
+package loader
+
+import (
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"k8s.io/klog/v2"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+const (
+	// DefaultPolicyResyncPeriod is the interval of re-reading policy file, which
+	// covers the file changes missed by the filesystem notifications.
+	DefaultPolicyResyncPeriod = time.Minute
+)
+
+// PolicyUpdateHandler is called with the latest valid scheduler policy.
+type PolicyUpdateHandler func(*policy.DynamicSchedulerPolicy)
+
+// PolicyWatcher watches the scheduler policy file, which may be mounted from a ConfigMap,
+// and notifies the handler once a changed and valid policy has been loaded.
+type PolicyWatcher struct {
+	file    string
+	current *policy.DynamicSchedulerPolicy
+	handler PolicyUpdateHandler
+}
+
+// NewPolicyWatcher returns a PolicyWatcher object, current is the policy which is in effect now.
+func NewPolicyWatcher(file string, current *policy.DynamicSchedulerPolicy, handler PolicyUpdateHandler) *PolicyWatcher {
+	return &PolicyWatcher{
+		file:    file,
+		current: current,
+		handler: handler,
+	}
+}
+
+// Run watches the policy file until stopCh is closed.
+func (w *PolicyWatcher) Run(stopCh <-chan struct{}) {
+	var events <-chan fsnotify.Event
+	var watchErrors <-chan error
+
+	// Watch the parent directory rather than the file itself, since ConfigMap volumes
+	// are updated by atomically swapping the symlink of the data directory.
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		klog.Warningf("Failed to create watcher for policy file %s, fall back to polling: %v", w.file, err)
+	} else {
+		defer watcher.Close()
+		if err := watcher.Add(filepath.Dir(w.file)); err != nil {
+			klog.Warningf("Failed to watch policy file %s, fall back to polling: %v", w.file, err)
+		} else {
+			events, watchErrors = watcher.Events, watcher.Errors
+		}
+	}
+
+	ticker := time.NewTicker(DefaultPolicyResyncPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event := <-events:
+			klog.V(4).Infof("Policy watcher received event %v", event)
+			w.reload()
+		case err := <-watchErrors:
+			klog.Warningf("Policy watcher error: %v", err)
+		case <-ticker.C:
+			w.reload()
+		case <-stopCh:
+			return
+		}
+	}
+}
+
+func (w *PolicyWatcher) reload() {
+	newPolicy, err := LoadPolicyFromFile(w.file)
+	if err != nil {
+		klog.Errorf("Failed to reload policy from %s, keep using the current one: %v", w.file, err)
+		return
+	}
+
+	if w.current != nil && reflect.DeepEqual(w.current.Spec, newPolicy.Spec) {
+		return
+	}
+
+	klog.Infof("Scheduler policy %s changed, apply the new one", w.file)
+
+	w.current = newPolicy
+	w.handler(newPolicy)
+}
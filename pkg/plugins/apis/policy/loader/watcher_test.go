@@ -0,0 +1,112 @@
+package loader
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+const testPolicyTemplate = `apiVersion: scheduler.policy.crane.io/v1alpha1
+kind: DynamicSchedulerPolicy
+spec:
+  syncPolicy:
+    - name: cpu_usage_avg_5m
+      period: 3m
+  predicate:
+    - name: cpu_usage_avg_5m
+      maxLimitPecent: %s
+  priority:
+    - name: cpu_usage_avg_5m
+      weight: 0.2
+`
+
+func writeTestPolicy(t *testing.T, file, maxLimitPercent string) {
+	data := []byte(fmt.Sprintf(testPolicyTemplate, maxLimitPercent))
+	if err := ioutil.WriteFile(file, data, 0644); err != nil {
+		t.Fatalf("failed to write policy file: %v", err)
+	}
+}
+
+func TestPolicyWatcher(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	writeTestPolicy(t, file, "0.65")
+
+	current, err := LoadPolicyFromFile(file)
+	if err != nil {
+		t.Fatalf("LoadPolicyFromFile() error = %v", err)
+	}
+
+	updates := make(chan *policy.DynamicSchedulerPolicy, 10)
+	w := NewPolicyWatcher(file, current, func(p *policy.DynamicSchedulerPolicy) {
+		updates <- p
+	})
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go w.Run(stopCh)
+
+	// the watch is set up asynchronously, so the file is written until it is reloaded.
+	var updated *policy.DynamicSchedulerPolicy
+	for deadline := time.Now().Add(10 * time.Second); updated == nil && time.Now().Before(deadline); {
+		writeTestPolicy(t, file, "0.5")
+		select {
+		case updated = <-updates:
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	if updated == nil {
+		t.Fatalf("policy is not reloaded after the file changed")
+	}
+	if got := updated.Spec.Predicate[0].MaxLimitPecent; got != 0.5 {
+		t.Errorf("MaxLimitPecent = %v after reload, want 0.5", got)
+	}
+}
+
+func TestPolicyWatcherReload(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	writeTestPolicy(t, file, "0.65")
+
+	current, err := LoadPolicyFromFile(file)
+	if err != nil {
+		t.Fatalf("LoadPolicyFromFile() error = %v", err)
+	}
+
+	var handled []*policy.DynamicSchedulerPolicy
+	w := NewPolicyWatcher(file, current, func(p *policy.DynamicSchedulerPolicy) {
+		handled = append(handled, p)
+	})
+
+	// an unchanged policy is not applied again.
+	w.reload()
+	if len(handled) != 0 {
+		t.Fatalf("handler is called %d times for unchanged policy, want 0", len(handled))
+	}
+
+	// an invalid policy is ignored, and the last valid one is kept.
+	writeTestPolicy(t, file, "1.5")
+	w.reload()
+	if len(handled) != 0 || w.current != current {
+		t.Fatalf("invalid policy is applied")
+	}
+
+	if err := ioutil.WriteFile(file, []byte("spec: ["), 0644); err != nil {
+		t.Fatalf("failed to write policy file: %v", err)
+	}
+	w.reload()
+	if len(handled) != 0 || w.current != current {
+		t.Fatalf("malformed policy is applied")
+	}
+
+	writeTestPolicy(t, file, "0.5")
+	w.reload()
+	if len(handled) != 1 || handled[0].Spec.Predicate[0].MaxLimitPecent != 0.5 {
+		t.Fatalf("valid policy is not applied after invalid ones")
+	}
+	if w.current != handled[0] {
+		t.Errorf("current policy is not replaced by the applied one")
+	}
+}
@@ -8,32 +8,141 @@ import (
 
 type DynamicSchedulerPolicy struct {
 	metav1.TypeMeta
-	Spec PolicySpec
+	metav1.ObjectMeta
+
+	Spec   PolicySpec
+	Status PolicyStatus
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DynamicSchedulerPolicyList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []DynamicSchedulerPolicy
 }
 
 type PolicySpec struct {
 	SyncPeriod []SyncPolicy
 	Predicate  []PredicatePolicy
 	Priority   []PriorityPolicy
+	Balance    []BalancePolicy
 	HotValue   []HotValuePolicy
+	Profiles   []PolicyProfile
+	NodePools  []NodePoolPolicy
+	Exemptions *ExemptionRules
 }
 
 type SyncPolicy struct {
 	Name   string
 	Period metav1.Duration
+	// Query is the template of PromQL used to get the metric.
+	Query string
+	// NodeLabel is the name of label identifying node in the query result.
+	NodeLabel string
+	// Scale is the factor multiplied to the query result to get usage ratio.
+	Scale float64
 }
 
 type PredicatePolicy struct {
 	Name           string
 	MaxLimitPecent float64
+	// MissingData is how to treat nodes whose load data of the metric is missing or expired.
+	MissingData MissingDataPolicy
+	// Relaxation relaxes the threshold for pods pending long because all nodes are overloaded.
+	Relaxation *ThresholdRelaxation
+}
+
+type ThresholdRelaxation struct {
+	Step    float64
+	Ceiling float64
 }
 
 type PriorityPolicy struct {
 	Name   string
 	Weight float64
+	// MissingData is how to treat nodes whose load data of the metric is missing or expired.
+	MissingData MissingDataPolicy
+}
+
+type BalancePolicy struct {
+	Name    string
+	Metrics []string
+	Weight  float64
+}
+
+type PolicyProfile struct {
+	Name               string
+	PriorityClassNames []string
+	Predicate          []PredicatePolicy
+	Priority           []PriorityPolicy
+	Balance            []BalancePolicy
+}
+
+type NodePoolPolicy struct {
+	Name         string
+	NodeSelector *metav1.LabelSelector
+	SyncPeriod   []SyncPolicy
+	Predicate    []PredicatePolicy
+	Priority     []PriorityPolicy
+	Balance      []BalancePolicy
+	HotValue     []HotValuePolicy
 }
 
+type ExemptionRules struct {
+	PriorityClassNames []string
+	MinPriority        *int32
+	Namespaces         []string
+	PodSelector        *metav1.LabelSelector
+	OwnerKinds         []string
+}
+
+type MissingDataPolicy string
+
+const (
+	MissingDataIgnoreNode        MissingDataPolicy = "IgnoreNode"
+	MissingDataTreatAsOverloaded MissingDataPolicy = "TreatAsOverloaded"
+	MissingDataTreatAsIdle       MissingDataPolicy = "TreatAsIdle"
+	MissingDataUseLastKnown      MissingDataPolicy = "UseLastKnown"
+)
+
 type HotValuePolicy struct {
 	TimeRange metav1.Duration
 	Count     int
+	// Model is how bindings within TimeRange contribute to the hot value.
+	Model HotValueModel
+	// HalfLife is the half-life of bindings of ExponentialDecay model.
+	HalfLife metav1.Duration
+}
+
+type HotValueModel string
+
+const (
+	HotValueModelStep             HotValueModel = "Step"
+	HotValueModelLinear           HotValueModel = "Linear"
+	HotValueModelExponentialDecay HotValueModel = "ExponentialDecay"
+)
+
+type PolicyStatus struct {
+	ObservedGeneration int64
+	AnnotatedNodes     int32
+	SyncStatus         []MetricSyncStatus
+}
+
+type MetricSyncResult string
+
+const (
+	MetricSyncSucceeded       MetricSyncResult = "Succeeded"
+	MetricSyncPartiallyFailed MetricSyncResult = "PartiallyFailed"
+	MetricSyncFailed          MetricSyncResult = "Failed"
+)
+
+type MetricSyncStatus struct {
+	Name           string
+	LastSyncTime   metav1.Time
+	Result         MetricSyncResult
+	SucceededNodes int32
+	FailedNodes    int32
+	Message        string
 }
@@ -9,6 +9,7 @@ import (
 	unsafe "unsafe"
 
 	policy "github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -20,6 +21,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*BalancePolicy)(nil), (*policy.BalancePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_BalancePolicy_To_policy_BalancePolicy(a.(*BalancePolicy), b.(*policy.BalancePolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*policy.BalancePolicy)(nil), (*BalancePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_policy_BalancePolicy_To_v1alpha1_BalancePolicy(a.(*policy.BalancePolicy), b.(*BalancePolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DynamicSchedulerPolicy)(nil), (*policy.DynamicSchedulerPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_DynamicSchedulerPolicy_To_policy_DynamicSchedulerPolicy(a.(*DynamicSchedulerPolicy), b.(*policy.DynamicSchedulerPolicy), scope)
 	}); err != nil {
@@ -30,6 +41,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DynamicSchedulerPolicyList)(nil), (*policy.DynamicSchedulerPolicyList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DynamicSchedulerPolicyList_To_policy_DynamicSchedulerPolicyList(a.(*DynamicSchedulerPolicyList), b.(*policy.DynamicSchedulerPolicyList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*policy.DynamicSchedulerPolicyList)(nil), (*DynamicSchedulerPolicyList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_policy_DynamicSchedulerPolicyList_To_v1alpha1_DynamicSchedulerPolicyList(a.(*policy.DynamicSchedulerPolicyList), b.(*DynamicSchedulerPolicyList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ExemptionRules)(nil), (*policy.ExemptionRules)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ExemptionRules_To_policy_ExemptionRules(a.(*ExemptionRules), b.(*policy.ExemptionRules), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*policy.ExemptionRules)(nil), (*ExemptionRules)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_policy_ExemptionRules_To_v1alpha1_ExemptionRules(a.(*policy.ExemptionRules), b.(*ExemptionRules), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*HotValuePolicy)(nil), (*policy.HotValuePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_HotValuePolicy_To_policy_HotValuePolicy(a.(*HotValuePolicy), b.(*policy.HotValuePolicy), scope)
 	}); err != nil {
@@ -40,6 +71,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MetricSyncStatus)(nil), (*policy.MetricSyncStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MetricSyncStatus_To_policy_MetricSyncStatus(a.(*MetricSyncStatus), b.(*policy.MetricSyncStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*policy.MetricSyncStatus)(nil), (*MetricSyncStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_policy_MetricSyncStatus_To_v1alpha1_MetricSyncStatus(a.(*policy.MetricSyncStatus), b.(*MetricSyncStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodePoolPolicy)(nil), (*policy.NodePoolPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NodePoolPolicy_To_policy_NodePoolPolicy(a.(*NodePoolPolicy), b.(*policy.NodePoolPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*policy.NodePoolPolicy)(nil), (*NodePoolPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_policy_NodePoolPolicy_To_v1alpha1_NodePoolPolicy(a.(*policy.NodePoolPolicy), b.(*NodePoolPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PolicyProfile)(nil), (*policy.PolicyProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PolicyProfile_To_policy_PolicyProfile(a.(*PolicyProfile), b.(*policy.PolicyProfile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*policy.PolicyProfile)(nil), (*PolicyProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_policy_PolicyProfile_To_v1alpha1_PolicyProfile(a.(*policy.PolicyProfile), b.(*PolicyProfile), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PolicySpec)(nil), (*policy.PolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_PolicySpec_To_policy_PolicySpec(a.(*PolicySpec), b.(*policy.PolicySpec), scope)
 	}); err != nil {
@@ -50,6 +111,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PolicyStatus)(nil), (*policy.PolicyStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PolicyStatus_To_policy_PolicyStatus(a.(*PolicyStatus), b.(*policy.PolicyStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*policy.PolicyStatus)(nil), (*PolicyStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_policy_PolicyStatus_To_v1alpha1_PolicyStatus(a.(*policy.PolicyStatus), b.(*PolicyStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*PredicatePolicy)(nil), (*policy.PredicatePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_PredicatePolicy_To_policy_PredicatePolicy(a.(*PredicatePolicy), b.(*policy.PredicatePolicy), scope)
 	}); err != nil {
@@ -80,13 +151,51 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ThresholdRelaxation)(nil), (*policy.ThresholdRelaxation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ThresholdRelaxation_To_policy_ThresholdRelaxation(a.(*ThresholdRelaxation), b.(*policy.ThresholdRelaxation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*policy.ThresholdRelaxation)(nil), (*ThresholdRelaxation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_policy_ThresholdRelaxation_To_v1alpha1_ThresholdRelaxation(a.(*policy.ThresholdRelaxation), b.(*ThresholdRelaxation), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_BalancePolicy_To_policy_BalancePolicy(in *BalancePolicy, out *policy.BalancePolicy, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Metrics = *(*[]string)(unsafe.Pointer(&in.Metrics))
+	out.Weight = in.Weight
+	return nil
+}
+
+// Convert_v1alpha1_BalancePolicy_To_policy_BalancePolicy is an autogenerated conversion function.
+func Convert_v1alpha1_BalancePolicy_To_policy_BalancePolicy(in *BalancePolicy, out *policy.BalancePolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha1_BalancePolicy_To_policy_BalancePolicy(in, out, s)
+}
+
+func autoConvert_policy_BalancePolicy_To_v1alpha1_BalancePolicy(in *policy.BalancePolicy, out *BalancePolicy, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Metrics = *(*[]string)(unsafe.Pointer(&in.Metrics))
+	out.Weight = in.Weight
 	return nil
 }
 
+// Convert_policy_BalancePolicy_To_v1alpha1_BalancePolicy is an autogenerated conversion function.
+func Convert_policy_BalancePolicy_To_v1alpha1_BalancePolicy(in *policy.BalancePolicy, out *BalancePolicy, s conversion.Scope) error {
+	return autoConvert_policy_BalancePolicy_To_v1alpha1_BalancePolicy(in, out, s)
+}
+
 func autoConvert_v1alpha1_DynamicSchedulerPolicy_To_policy_DynamicSchedulerPolicy(in *DynamicSchedulerPolicy, out *policy.DynamicSchedulerPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha1_PolicySpec_To_policy_PolicySpec(&in.Spec, &out.Spec, s); err != nil {
 		return err
 	}
+	if err := Convert_v1alpha1_PolicyStatus_To_policy_PolicyStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -96,9 +205,13 @@ func Convert_v1alpha1_DynamicSchedulerPolicy_To_policy_DynamicSchedulerPolicy(in
 }
 
 func autoConvert_policy_DynamicSchedulerPolicy_To_v1alpha1_DynamicSchedulerPolicy(in *policy.DynamicSchedulerPolicy, out *DynamicSchedulerPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_policy_PolicySpec_To_v1alpha1_PolicySpec(&in.Spec, &out.Spec, s); err != nil {
 		return err
 	}
+	if err := Convert_policy_PolicyStatus_To_v1alpha1_PolicyStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -107,9 +220,61 @@ func Convert_policy_DynamicSchedulerPolicy_To_v1alpha1_DynamicSchedulerPolicy(in
 	return autoConvert_policy_DynamicSchedulerPolicy_To_v1alpha1_DynamicSchedulerPolicy(in, out, s)
 }
 
+func autoConvert_v1alpha1_DynamicSchedulerPolicyList_To_policy_DynamicSchedulerPolicyList(in *DynamicSchedulerPolicyList, out *policy.DynamicSchedulerPolicyList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]policy.DynamicSchedulerPolicy)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1alpha1_DynamicSchedulerPolicyList_To_policy_DynamicSchedulerPolicyList is an autogenerated conversion function.
+func Convert_v1alpha1_DynamicSchedulerPolicyList_To_policy_DynamicSchedulerPolicyList(in *DynamicSchedulerPolicyList, out *policy.DynamicSchedulerPolicyList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DynamicSchedulerPolicyList_To_policy_DynamicSchedulerPolicyList(in, out, s)
+}
+
+func autoConvert_policy_DynamicSchedulerPolicyList_To_v1alpha1_DynamicSchedulerPolicyList(in *policy.DynamicSchedulerPolicyList, out *DynamicSchedulerPolicyList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]DynamicSchedulerPolicy)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_policy_DynamicSchedulerPolicyList_To_v1alpha1_DynamicSchedulerPolicyList is an autogenerated conversion function.
+func Convert_policy_DynamicSchedulerPolicyList_To_v1alpha1_DynamicSchedulerPolicyList(in *policy.DynamicSchedulerPolicyList, out *DynamicSchedulerPolicyList, s conversion.Scope) error {
+	return autoConvert_policy_DynamicSchedulerPolicyList_To_v1alpha1_DynamicSchedulerPolicyList(in, out, s)
+}
+
+func autoConvert_v1alpha1_ExemptionRules_To_policy_ExemptionRules(in *ExemptionRules, out *policy.ExemptionRules, s conversion.Scope) error {
+	out.PriorityClassNames = *(*[]string)(unsafe.Pointer(&in.PriorityClassNames))
+	out.MinPriority = (*int32)(unsafe.Pointer(in.MinPriority))
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.PodSelector = (*v1.LabelSelector)(unsafe.Pointer(in.PodSelector))
+	out.OwnerKinds = *(*[]string)(unsafe.Pointer(&in.OwnerKinds))
+	return nil
+}
+
+// Convert_v1alpha1_ExemptionRules_To_policy_ExemptionRules is an autogenerated conversion function.
+func Convert_v1alpha1_ExemptionRules_To_policy_ExemptionRules(in *ExemptionRules, out *policy.ExemptionRules, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ExemptionRules_To_policy_ExemptionRules(in, out, s)
+}
+
+func autoConvert_policy_ExemptionRules_To_v1alpha1_ExemptionRules(in *policy.ExemptionRules, out *ExemptionRules, s conversion.Scope) error {
+	out.PriorityClassNames = *(*[]string)(unsafe.Pointer(&in.PriorityClassNames))
+	out.MinPriority = (*int32)(unsafe.Pointer(in.MinPriority))
+	out.Namespaces = *(*[]string)(unsafe.Pointer(&in.Namespaces))
+	out.PodSelector = (*v1.LabelSelector)(unsafe.Pointer(in.PodSelector))
+	out.OwnerKinds = *(*[]string)(unsafe.Pointer(&in.OwnerKinds))
+	return nil
+}
+
+// Convert_policy_ExemptionRules_To_v1alpha1_ExemptionRules is an autogenerated conversion function.
+func Convert_policy_ExemptionRules_To_v1alpha1_ExemptionRules(in *policy.ExemptionRules, out *ExemptionRules, s conversion.Scope) error {
+	return autoConvert_policy_ExemptionRules_To_v1alpha1_ExemptionRules(in, out, s)
+}
+
 func autoConvert_v1alpha1_HotValuePolicy_To_policy_HotValuePolicy(in *HotValuePolicy, out *policy.HotValuePolicy, s conversion.Scope) error {
 	out.TimeRange = in.TimeRange
 	out.Count = in.Count
+	out.Model = policy.HotValueModel(in.Model)
+	out.HalfLife = in.HalfLife
 	return nil
 }
 
@@ -121,6 +286,8 @@ func Convert_v1alpha1_HotValuePolicy_To_policy_HotValuePolicy(in *HotValuePolicy
 func autoConvert_policy_HotValuePolicy_To_v1alpha1_HotValuePolicy(in *policy.HotValuePolicy, out *HotValuePolicy, s conversion.Scope) error {
 	out.TimeRange = in.TimeRange
 	out.Count = in.Count
+	out.Model = HotValueModel(in.Model)
+	out.HalfLife = in.HalfLife
 	return nil
 }
 
@@ -129,11 +296,105 @@ func Convert_policy_HotValuePolicy_To_v1alpha1_HotValuePolicy(in *policy.HotValu
 	return autoConvert_policy_HotValuePolicy_To_v1alpha1_HotValuePolicy(in, out, s)
 }
 
+func autoConvert_v1alpha1_MetricSyncStatus_To_policy_MetricSyncStatus(in *MetricSyncStatus, out *policy.MetricSyncStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.LastSyncTime = in.LastSyncTime
+	out.Result = policy.MetricSyncResult(in.Result)
+	out.SucceededNodes = in.SucceededNodes
+	out.FailedNodes = in.FailedNodes
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1alpha1_MetricSyncStatus_To_policy_MetricSyncStatus is an autogenerated conversion function.
+func Convert_v1alpha1_MetricSyncStatus_To_policy_MetricSyncStatus(in *MetricSyncStatus, out *policy.MetricSyncStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MetricSyncStatus_To_policy_MetricSyncStatus(in, out, s)
+}
+
+func autoConvert_policy_MetricSyncStatus_To_v1alpha1_MetricSyncStatus(in *policy.MetricSyncStatus, out *MetricSyncStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.LastSyncTime = in.LastSyncTime
+	out.Result = MetricSyncResult(in.Result)
+	out.SucceededNodes = in.SucceededNodes
+	out.FailedNodes = in.FailedNodes
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_policy_MetricSyncStatus_To_v1alpha1_MetricSyncStatus is an autogenerated conversion function.
+func Convert_policy_MetricSyncStatus_To_v1alpha1_MetricSyncStatus(in *policy.MetricSyncStatus, out *MetricSyncStatus, s conversion.Scope) error {
+	return autoConvert_policy_MetricSyncStatus_To_v1alpha1_MetricSyncStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_NodePoolPolicy_To_policy_NodePoolPolicy(in *NodePoolPolicy, out *policy.NodePoolPolicy, s conversion.Scope) error {
+	out.Name = in.Name
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	out.SyncPeriod = *(*[]policy.SyncPolicy)(unsafe.Pointer(&in.SyncPeriod))
+	out.Predicate = *(*[]policy.PredicatePolicy)(unsafe.Pointer(&in.Predicate))
+	out.Priority = *(*[]policy.PriorityPolicy)(unsafe.Pointer(&in.Priority))
+	out.Balance = *(*[]policy.BalancePolicy)(unsafe.Pointer(&in.Balance))
+	out.HotValue = *(*[]policy.HotValuePolicy)(unsafe.Pointer(&in.HotValue))
+	return nil
+}
+
+// Convert_v1alpha1_NodePoolPolicy_To_policy_NodePoolPolicy is an autogenerated conversion function.
+func Convert_v1alpha1_NodePoolPolicy_To_policy_NodePoolPolicy(in *NodePoolPolicy, out *policy.NodePoolPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NodePoolPolicy_To_policy_NodePoolPolicy(in, out, s)
+}
+
+func autoConvert_policy_NodePoolPolicy_To_v1alpha1_NodePoolPolicy(in *policy.NodePoolPolicy, out *NodePoolPolicy, s conversion.Scope) error {
+	out.Name = in.Name
+	out.NodeSelector = (*v1.LabelSelector)(unsafe.Pointer(in.NodeSelector))
+	out.SyncPeriod = *(*[]SyncPolicy)(unsafe.Pointer(&in.SyncPeriod))
+	out.Predicate = *(*[]PredicatePolicy)(unsafe.Pointer(&in.Predicate))
+	out.Priority = *(*[]PriorityPolicy)(unsafe.Pointer(&in.Priority))
+	out.Balance = *(*[]BalancePolicy)(unsafe.Pointer(&in.Balance))
+	out.HotValue = *(*[]HotValuePolicy)(unsafe.Pointer(&in.HotValue))
+	return nil
+}
+
+// Convert_policy_NodePoolPolicy_To_v1alpha1_NodePoolPolicy is an autogenerated conversion function.
+func Convert_policy_NodePoolPolicy_To_v1alpha1_NodePoolPolicy(in *policy.NodePoolPolicy, out *NodePoolPolicy, s conversion.Scope) error {
+	return autoConvert_policy_NodePoolPolicy_To_v1alpha1_NodePoolPolicy(in, out, s)
+}
+
+func autoConvert_v1alpha1_PolicyProfile_To_policy_PolicyProfile(in *PolicyProfile, out *policy.PolicyProfile, s conversion.Scope) error {
+	out.Name = in.Name
+	out.PriorityClassNames = *(*[]string)(unsafe.Pointer(&in.PriorityClassNames))
+	out.Predicate = *(*[]policy.PredicatePolicy)(unsafe.Pointer(&in.Predicate))
+	out.Priority = *(*[]policy.PriorityPolicy)(unsafe.Pointer(&in.Priority))
+	out.Balance = *(*[]policy.BalancePolicy)(unsafe.Pointer(&in.Balance))
+	return nil
+}
+
+// Convert_v1alpha1_PolicyProfile_To_policy_PolicyProfile is an autogenerated conversion function.
+func Convert_v1alpha1_PolicyProfile_To_policy_PolicyProfile(in *PolicyProfile, out *policy.PolicyProfile, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PolicyProfile_To_policy_PolicyProfile(in, out, s)
+}
+
+func autoConvert_policy_PolicyProfile_To_v1alpha1_PolicyProfile(in *policy.PolicyProfile, out *PolicyProfile, s conversion.Scope) error {
+	out.Name = in.Name
+	out.PriorityClassNames = *(*[]string)(unsafe.Pointer(&in.PriorityClassNames))
+	out.Predicate = *(*[]PredicatePolicy)(unsafe.Pointer(&in.Predicate))
+	out.Priority = *(*[]PriorityPolicy)(unsafe.Pointer(&in.Priority))
+	out.Balance = *(*[]BalancePolicy)(unsafe.Pointer(&in.Balance))
+	return nil
+}
+
+// Convert_policy_PolicyProfile_To_v1alpha1_PolicyProfile is an autogenerated conversion function.
+func Convert_policy_PolicyProfile_To_v1alpha1_PolicyProfile(in *policy.PolicyProfile, out *PolicyProfile, s conversion.Scope) error {
+	return autoConvert_policy_PolicyProfile_To_v1alpha1_PolicyProfile(in, out, s)
+}
+
 func autoConvert_v1alpha1_PolicySpec_To_policy_PolicySpec(in *PolicySpec, out *policy.PolicySpec, s conversion.Scope) error {
 	out.SyncPeriod = *(*[]policy.SyncPolicy)(unsafe.Pointer(&in.SyncPeriod))
 	out.Predicate = *(*[]policy.PredicatePolicy)(unsafe.Pointer(&in.Predicate))
 	out.Priority = *(*[]policy.PriorityPolicy)(unsafe.Pointer(&in.Priority))
+	out.Balance = *(*[]policy.BalancePolicy)(unsafe.Pointer(&in.Balance))
 	out.HotValue = *(*[]policy.HotValuePolicy)(unsafe.Pointer(&in.HotValue))
+	out.Profiles = *(*[]policy.PolicyProfile)(unsafe.Pointer(&in.Profiles))
+	out.NodePools = *(*[]policy.NodePoolPolicy)(unsafe.Pointer(&in.NodePools))
+	out.Exemptions = (*policy.ExemptionRules)(unsafe.Pointer(in.Exemptions))
 	return nil
 }
 
@@ -146,7 +407,11 @@ func autoConvert_policy_PolicySpec_To_v1alpha1_PolicySpec(in *policy.PolicySpec,
 	out.SyncPeriod = *(*[]SyncPolicy)(unsafe.Pointer(&in.SyncPeriod))
 	out.Predicate = *(*[]PredicatePolicy)(unsafe.Pointer(&in.Predicate))
 	out.Priority = *(*[]PriorityPolicy)(unsafe.Pointer(&in.Priority))
+	out.Balance = *(*[]BalancePolicy)(unsafe.Pointer(&in.Balance))
 	out.HotValue = *(*[]HotValuePolicy)(unsafe.Pointer(&in.HotValue))
+	out.Profiles = *(*[]PolicyProfile)(unsafe.Pointer(&in.Profiles))
+	out.NodePools = *(*[]NodePoolPolicy)(unsafe.Pointer(&in.NodePools))
+	out.Exemptions = (*ExemptionRules)(unsafe.Pointer(in.Exemptions))
 	return nil
 }
 
@@ -155,9 +420,35 @@ func Convert_policy_PolicySpec_To_v1alpha1_PolicySpec(in *policy.PolicySpec, out
 	return autoConvert_policy_PolicySpec_To_v1alpha1_PolicySpec(in, out, s)
 }
 
+func autoConvert_v1alpha1_PolicyStatus_To_policy_PolicyStatus(in *PolicyStatus, out *policy.PolicyStatus, s conversion.Scope) error {
+	out.ObservedGeneration = in.ObservedGeneration
+	out.AnnotatedNodes = in.AnnotatedNodes
+	out.SyncStatus = *(*[]policy.MetricSyncStatus)(unsafe.Pointer(&in.SyncStatus))
+	return nil
+}
+
+// Convert_v1alpha1_PolicyStatus_To_policy_PolicyStatus is an autogenerated conversion function.
+func Convert_v1alpha1_PolicyStatus_To_policy_PolicyStatus(in *PolicyStatus, out *policy.PolicyStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PolicyStatus_To_policy_PolicyStatus(in, out, s)
+}
+
+func autoConvert_policy_PolicyStatus_To_v1alpha1_PolicyStatus(in *policy.PolicyStatus, out *PolicyStatus, s conversion.Scope) error {
+	out.ObservedGeneration = in.ObservedGeneration
+	out.AnnotatedNodes = in.AnnotatedNodes
+	out.SyncStatus = *(*[]MetricSyncStatus)(unsafe.Pointer(&in.SyncStatus))
+	return nil
+}
+
+// Convert_policy_PolicyStatus_To_v1alpha1_PolicyStatus is an autogenerated conversion function.
+func Convert_policy_PolicyStatus_To_v1alpha1_PolicyStatus(in *policy.PolicyStatus, out *PolicyStatus, s conversion.Scope) error {
+	return autoConvert_policy_PolicyStatus_To_v1alpha1_PolicyStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_PredicatePolicy_To_policy_PredicatePolicy(in *PredicatePolicy, out *policy.PredicatePolicy, s conversion.Scope) error {
 	out.Name = in.Name
 	out.MaxLimitPecent = in.MaxLimitPecent
+	out.MissingData = policy.MissingDataPolicy(in.MissingData)
+	out.Relaxation = (*policy.ThresholdRelaxation)(unsafe.Pointer(in.Relaxation))
 	return nil
 }
 
@@ -169,6 +460,8 @@ func Convert_v1alpha1_PredicatePolicy_To_policy_PredicatePolicy(in *PredicatePol
 func autoConvert_policy_PredicatePolicy_To_v1alpha1_PredicatePolicy(in *policy.PredicatePolicy, out *PredicatePolicy, s conversion.Scope) error {
 	out.Name = in.Name
 	out.MaxLimitPecent = in.MaxLimitPecent
+	out.MissingData = MissingDataPolicy(in.MissingData)
+	out.Relaxation = (*ThresholdRelaxation)(unsafe.Pointer(in.Relaxation))
 	return nil
 }
 
@@ -180,6 +473,7 @@ func Convert_policy_PredicatePolicy_To_v1alpha1_PredicatePolicy(in *policy.Predi
 func autoConvert_v1alpha1_PriorityPolicy_To_policy_PriorityPolicy(in *PriorityPolicy, out *policy.PriorityPolicy, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Weight = in.Weight
+	out.MissingData = policy.MissingDataPolicy(in.MissingData)
 	return nil
 }
 
@@ -191,6 +485,7 @@ func Convert_v1alpha1_PriorityPolicy_To_policy_PriorityPolicy(in *PriorityPolicy
 func autoConvert_policy_PriorityPolicy_To_v1alpha1_PriorityPolicy(in *policy.PriorityPolicy, out *PriorityPolicy, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Weight = in.Weight
+	out.MissingData = MissingDataPolicy(in.MissingData)
 	return nil
 }
 
@@ -202,6 +497,9 @@ func Convert_policy_PriorityPolicy_To_v1alpha1_PriorityPolicy(in *policy.Priorit
 func autoConvert_v1alpha1_SyncPolicy_To_policy_SyncPolicy(in *SyncPolicy, out *policy.SyncPolicy, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Period = in.Period
+	out.Query = in.Query
+	out.NodeLabel = in.NodeLabel
+	out.Scale = in.Scale
 	return nil
 }
 
@@ -213,6 +511,9 @@ func Convert_v1alpha1_SyncPolicy_To_policy_SyncPolicy(in *SyncPolicy, out *polic
 func autoConvert_policy_SyncPolicy_To_v1alpha1_SyncPolicy(in *policy.SyncPolicy, out *SyncPolicy, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Period = in.Period
+	out.Query = in.Query
+	out.NodeLabel = in.NodeLabel
+	out.Scale = in.Scale
 	return nil
 }
 
@@ -220,3 +521,25 @@ func autoConvert_policy_SyncPolicy_To_v1alpha1_SyncPolicy(in *policy.SyncPolicy,
 func Convert_policy_SyncPolicy_To_v1alpha1_SyncPolicy(in *policy.SyncPolicy, out *SyncPolicy, s conversion.Scope) error {
 	return autoConvert_policy_SyncPolicy_To_v1alpha1_SyncPolicy(in, out, s)
 }
+
+func autoConvert_v1alpha1_ThresholdRelaxation_To_policy_ThresholdRelaxation(in *ThresholdRelaxation, out *policy.ThresholdRelaxation, s conversion.Scope) error {
+	out.Step = in.Step
+	out.Ceiling = in.Ceiling
+	return nil
+}
+
+// Convert_v1alpha1_ThresholdRelaxation_To_policy_ThresholdRelaxation is an autogenerated conversion function.
+func Convert_v1alpha1_ThresholdRelaxation_To_policy_ThresholdRelaxation(in *ThresholdRelaxation, out *policy.ThresholdRelaxation, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ThresholdRelaxation_To_policy_ThresholdRelaxation(in, out, s)
+}
+
+func autoConvert_policy_ThresholdRelaxation_To_v1alpha1_ThresholdRelaxation(in *policy.ThresholdRelaxation, out *ThresholdRelaxation, s conversion.Scope) error {
+	out.Step = in.Step
+	out.Ceiling = in.Ceiling
+	return nil
+}
+
+// Convert_policy_ThresholdRelaxation_To_v1alpha1_ThresholdRelaxation is an autogenerated conversion function.
+func Convert_policy_ThresholdRelaxation_To_v1alpha1_ThresholdRelaxation(in *policy.ThresholdRelaxation, out *ThresholdRelaxation, s conversion.Scope) error {
+	return autoConvert_policy_ThresholdRelaxation_To_v1alpha1_ThresholdRelaxation(in, out, s)
+}
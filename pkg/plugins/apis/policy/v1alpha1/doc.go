@@ -1,4 +1,5 @@
 // +k8s:deepcopy-gen=package,register
 // +k8s:conversion-gen=github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy
+// +groupName=scheduler.policy.crane.io
 
 package v1alpha1 // import "crane.io/crane-scheduler/pkg/plugins/apis/policy/v1alpha1"
@@ -4,36 +4,261 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// +genclient
+// +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope="Cluster",shortName=dsp
+// +kubebuilder:subresource:status
 
 type DynamicSchedulerPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PolicySpec   `json:"spec"`
+	Status PolicyStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DynamicSchedulerPolicyList is a list of DynamicSchedulerPolicy objects.
+type DynamicSchedulerPolicyList struct {
 	metav1.TypeMeta `json:",inline"`
-	Spec            PolicySpec `json:"spec"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []DynamicSchedulerPolicy `json:"items"`
 }
 
 type PolicySpec struct {
 	SyncPeriod []SyncPolicy      `json:"syncPolicy"`
 	Predicate  []PredicatePolicy `json:"predicate"`
 	Priority   []PriorityPolicy  `json:"priority"`
-	HotValue   []HotValuePolicy  `json:"hotValue"`
+	// Balance are the groups of metrics whose usage should be balanced, such as cpu and memory,
+	// nodes whose usage of metrics in a group differ more get lower scores.
+	// +optional
+	Balance  []BalancePolicy  `json:"balance,omitempty"`
+	HotValue []HotValuePolicy `json:"hotValue"`
+	// Profiles are named overrides of predicate and priority policies, which are selected by
+	// workloads, so that co-located workloads get different load tolerance.
+	// +optional
+	Profiles []PolicyProfile `json:"profiles,omitempty"`
+	// NodePools are rule sets for the nodes selected by label, such as GPU or memory-optimized
+	// nodes, which override the policies of spec for those nodes.
+	// +optional
+	NodePools []NodePoolPolicy `json:"nodePools,omitempty"`
+	// Exemptions are the rules of pods exempted from load filtering, besides DaemonSet pods.
+	// +optional
+	Exemptions *ExemptionRules `json:"exemptions,omitempty"`
 }
 
 type SyncPolicy struct {
 	Name   string          `json:"name"`
 	Period metav1.Duration `json:"period"`
+	// Query is the Go template of PromQL used to get the metric from Prometheus, which
+	// can refer to {{ .Metric }}, the name of metric, and {{ .Selector }}, the label selector
+	// of node such as {instance=~"10.0.0.1(:.+)?"}, or {{ .LabelMatcher }}, the selector without
+	// braces. Selector and LabelMatcher are empty when querying all nodes at once.
+	// Defaults to "{{ .Metric }}{{ .Selector }}".
+	// +optional
+	Query string `json:"query,omitempty"`
+	// NodeLabel is the name of label identifying node in the query result, whose value is
+	// node IP or node name, with an optional port. Defaults to "instance".
+	// +optional
+	NodeLabel string `json:"nodeLabel,omitempty"`
+	// Scale is the factor multiplied to the query result to get usage ratio in range [0, 1].
+	// Defaults to 0.01, as the recording rules produce percentages.
+	// +optional
+	Scale float64 `json:"scale,omitempty"`
 }
 
 type PredicatePolicy struct {
 	Name           string  `json:"name"`
 	MaxLimitPecent float64 `json:"maxLimitPecent"`
+	// MissingData is how to treat nodes whose load data of the metric is missing or expired.
+	// Defaults to IgnoreNode, which means the node passes the predicate.
+	// +optional
+	MissingData MissingDataPolicy `json:"missingData,omitempty"`
+	// Relaxation relaxes the threshold for pods which have been pending long because all
+	// nodes are overloaded. No relaxation if not set.
+	// +optional
+	Relaxation *ThresholdRelaxation `json:"relaxation,omitempty"`
+}
+
+// ThresholdRelaxation relaxes MaxLimitPecent of predicate progressively for pending pods.
+type ThresholdRelaxation struct {
+	// Step is added to the threshold every time the pod has been pending for another
+	// threshold relaxation delay of the Dynamic plugin.
+	Step float64 `json:"step"`
+	// Ceiling is the max threshold after relaxation.
+	Ceiling float64 `json:"ceiling"`
 }
 
 type PriorityPolicy struct {
 	Name   string  `json:"name"`
 	Weight float64 `json:"weight"`
+	// MissingData is how to treat nodes whose load data of the metric is missing or expired.
+	// Defaults to TreatAsOverloaded, which means the node gets no score of the priority.
+	// +optional
+	MissingData MissingDataPolicy `json:"missingData,omitempty"`
+}
+
+// BalancePolicy scores nodes by how balanced the usage of its metrics is, like the
+// NodeResourcesBalancedAllocation plugin but on the real usage of nodes.
+type BalancePolicy struct {
+	Name string `json:"name"`
+	// Metrics are the metrics balanced against each other, such as cpu_usage_avg_5m and
+	// mem_usage_avg_5m, at least two are required.
+	Metrics []string `json:"metrics"`
+	// Weight is the weight of balance score, which is weighted together with priority policies.
+	Weight float64 `json:"weight"`
+}
+
+// PolicyProfile overrides the predicate, priority and balance policies for the pods selecting it, by
+// the annotation scheduler.crane.io/policy-profile of pod, the label of the same key of its
+// namespace, or its PriorityClass, in the order of precedence.
+type PolicyProfile struct {
+	Name string `json:"name"`
+	// PriorityClassNames are the PriorityClasses whose pods select the profile.
+	// +optional
+	PriorityClassNames []string `json:"priorityClassNames,omitempty"`
+	// Predicate replaces the predicate policies of spec if not empty.
+	// +optional
+	Predicate []PredicatePolicy `json:"predicate,omitempty"`
+	// Priority replaces the priority policies of spec if not empty.
+	// +optional
+	Priority []PriorityPolicy `json:"priority,omitempty"`
+	// Balance replaces the balance policies of spec if not empty.
+	// +optional
+	Balance []BalancePolicy `json:"balance,omitempty"`
+}
+
+// NodePoolPolicy overrides the sync, predicate, priority, balance and hot value policies for the
+// nodes matching its node selector. A node belongs to the first node pool it matches. Policy profiles selected by
+// pods take precedence over node pools.
+type NodePoolPolicy struct {
+	Name string `json:"name"`
+	// NodeSelector selects the nodes of the pool by labels.
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector"`
+	// SyncPeriod replaces the sync policies of spec if not empty.
+	// +optional
+	SyncPeriod []SyncPolicy `json:"syncPolicy,omitempty"`
+	// Predicate replaces the predicate policies of spec if not empty.
+	// +optional
+	Predicate []PredicatePolicy `json:"predicate,omitempty"`
+	// Priority replaces the priority policies of spec if not empty.
+	// +optional
+	Priority []PriorityPolicy `json:"priority,omitempty"`
+	// Balance replaces the balance policies of spec if not empty.
+	// +optional
+	Balance []BalancePolicy `json:"balance,omitempty"`
+	// HotValue replaces the hot value policies of spec if not empty.
+	// +optional
+	HotValue []HotValuePolicy `json:"hotValue,omitempty"`
 }
 
+// ExemptionRules selects the pods which are never filtered out for node load, such as critical
+// pods, static pods and operator-managed pods. A pod matching any of the rules is exempted.
+type ExemptionRules struct {
+	// PriorityClassNames exempts the pods of these PriorityClasses, such as system-node-critical.
+	// +optional
+	PriorityClassNames []string `json:"priorityClassNames,omitempty"`
+	// MinPriority exempts the pods whose priority is not less than it.
+	// +optional
+	MinPriority *int32 `json:"minPriority,omitempty"`
+	// Namespaces exempts the pods in these namespaces.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// PodSelector exempts the pods matching it.
+	// +optional
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+	// OwnerKinds exempts the pods owned by these kinds of controllers, for example, Node for
+	// static pods.
+	// +optional
+	OwnerKinds []string `json:"ownerKinds,omitempty"`
+}
+
+// MissingDataPolicy is how to treat nodes whose load data is missing or expired, for example,
+// when Prometheus is down.
+type MissingDataPolicy string
+
+const (
+	// MissingDataIgnoreNode excludes the predicate or priority from the node, that is, the node
+	// passes the predicate, and the weight of priority is not counted in the score of the node.
+	MissingDataIgnoreNode MissingDataPolicy = "IgnoreNode"
+	// MissingDataTreatAsOverloaded treats the node as fully loaded, that is, the node is filtered
+	// out by the predicate, and gets no score of the priority.
+	MissingDataTreatAsOverloaded MissingDataPolicy = "TreatAsOverloaded"
+	// MissingDataTreatAsIdle treats the node as idle, that is, the node passes the predicate,
+	// and gets the full score of the priority.
+	MissingDataTreatAsIdle MissingDataPolicy = "TreatAsIdle"
+	// MissingDataUseLastKnown uses the expired value as it is, and falls back to IgnoreNode if
+	// there is no value at all.
+	MissingDataUseLastKnown MissingDataPolicy = "UseLastKnown"
+)
+
 type HotValuePolicy struct {
 	TimeRange metav1.Duration `json:"timeRange"`
 	Count     int             `json:"count"`
+	// Model is how bindings within TimeRange contribute to the hot value, which is one of
+	// Step, Linear and ExponentialDecay. Defaults to Step.
+	// +optional
+	Model HotValueModel `json:"model,omitempty"`
+	// HalfLife is the half-life of bindings of ExponentialDecay model, required by it.
+	// +optional
+	HalfLife metav1.Duration `json:"halfLife,omitempty"`
+}
+
+// HotValueModel is how bindings of a node contribute to its hot value.
+type HotValueModel string
+
+const (
+	// HotValueModelStep adds the number of bindings within TimeRange divided by Count, rounded
+	// down, so the hot value jumps every Count bindings.
+	HotValueModelStep HotValueModel = "Step"
+	// HotValueModelLinear adds 1/Count for each binding within TimeRange, decaying linearly to
+	// 0 at the end of TimeRange.
+	HotValueModelLinear HotValueModel = "Linear"
+	// HotValueModelExponentialDecay adds 1/Count for each binding within TimeRange, halving
+	// every HalfLife.
+	HotValueModelExponentialDecay HotValueModel = "ExponentialDecay"
+)
+
+// PolicyStatus is written by the controller, showing whether the policy is in effect.
+type PolicyStatus struct {
+	// ObservedGeneration is the generation of the policy applied by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// AnnotatedNodes is the number of nodes annotated with load data successfully.
+	// +optional
+	AnnotatedNodes int32 `json:"annotatedNodes,omitempty"`
+	// SyncStatus is the last sync result of each metric in sync policy.
+	// +optional
+	SyncStatus []MetricSyncStatus `json:"syncStatus,omitempty"`
+}
+
+// MetricSyncResult is the result of syncing one metric to nodes.
+type MetricSyncResult string
+
+const (
+	MetricSyncSucceeded       MetricSyncResult = "Succeeded"
+	MetricSyncPartiallyFailed MetricSyncResult = "PartiallyFailed"
+	MetricSyncFailed          MetricSyncResult = "Failed"
+)
+
+// MetricSyncStatus describes the last sync result of one metric.
+type MetricSyncStatus struct {
+	Name string `json:"name"`
+	// LastSyncTime is the last time the metric was synced to any node.
+	// +optional
+	LastSyncTime metav1.Time `json:"lastSyncTime,omitempty"`
+	// +optional
+	Result MetricSyncResult `json:"result,omitempty"`
+	// SucceededNodes is the number of nodes whose last sync of this metric succeeded.
+	// +optional
+	SucceededNodes int32 `json:"succeededNodes,omitempty"`
+	// FailedNodes is the number of nodes whose last sync of this metric failed.
+	// +optional
+	FailedNodes int32 `json:"failedNodes,omitempty"`
+	// Message is the last error met when syncing this metric.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
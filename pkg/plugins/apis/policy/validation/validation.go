@@ -0,0 +1,336 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"text/template"
+
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+// supportedMissingDataPolicies are the valid values of MissingDataPolicy, empty means the default.
+var supportedMissingDataPolicies = sets.NewString("",
+	string(policy.MissingDataIgnoreNode),
+	string(policy.MissingDataTreatAsOverloaded),
+	string(policy.MissingDataTreatAsIdle),
+	string(policy.MissingDataUseLastKnown),
+)
+
+// supportedHotValueModels are the valid values of HotValueModel besides empty.
+var supportedHotValueModels = []string{
+	string(policy.HotValueModelStep),
+	string(policy.HotValueModelLinear),
+	string(policy.HotValueModelExponentialDecay),
+}
+
+// labelNameRegexp is the pattern of valid Prometheus label names.
+var labelNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// ValidateDynamicSchedulerPolicy validates a DynamicSchedulerPolicy and returns all errors found.
+func ValidateDynamicSchedulerPolicy(p *policy.DynamicSchedulerPolicy) field.ErrorList {
+	return ValidatePolicySpec(&p.Spec, field.NewPath("spec"))
+}
+
+// ValidatePolicySpec validates a PolicySpec.
+func ValidatePolicySpec(spec *policy.PolicySpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	syncErrs, syncedMetrics := validateSyncPolicies(spec.SyncPeriod, fldPath.Child("syncPolicy"))
+	allErrs = append(allErrs, syncErrs...)
+
+	allErrs = append(allErrs, validatePredicates(spec.Predicate, syncedMetrics, fldPath.Child("predicate"))...)
+	allErrs = append(allErrs, validatePriorities(spec.Priority, syncedMetrics, fldPath.Child("priority"))...)
+	allErrs = append(allErrs, validateBalances(spec.Balance, syncedMetrics, fldPath.Child("balance"))...)
+
+	allErrs = append(allErrs, validateHotValues(spec.HotValue, fldPath.Child("hotValue"))...)
+
+	// poolSyncedMetrics are the metrics synced for each node pool with its own sync policies.
+	poolSyncedMetrics := map[string]sets.String{}
+	poolNames := sets.NewString()
+	poolsPath := fldPath.Child("nodePools")
+	for i, pool := range spec.NodePools {
+		idxPath := poolsPath.Index(i)
+		if pool.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "node pool name is required"))
+		} else if poolNames.Has(pool.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), pool.Name))
+		}
+		poolNames.Insert(pool.Name)
+
+		if pool.NodeSelector == nil {
+			allErrs = append(allErrs, field.Required(idxPath.Child("nodeSelector"), "node selector is required"))
+		} else {
+			allErrs = append(allErrs, metav1validation.ValidateLabelSelector(pool.NodeSelector, idxPath.Child("nodeSelector"))...)
+		}
+
+		poolMetrics := syncedMetrics
+		if len(pool.SyncPeriod) > 0 {
+			var syncErrs field.ErrorList
+			syncErrs, poolMetrics = validateSyncPolicies(pool.SyncPeriod, idxPath.Child("syncPolicy"))
+			allErrs = append(allErrs, syncErrs...)
+			if pool.Name != "" {
+				poolSyncedMetrics[pool.Name] = poolMetrics
+			}
+		}
+
+		if len(pool.Predicate) > 0 {
+			allErrs = append(allErrs, validatePredicates(pool.Predicate, poolMetrics, idxPath.Child("predicate"))...)
+		} else if len(pool.SyncPeriod) > 0 {
+			// the predicate policies inherited from spec must be synced for the pool as well.
+			for _, pp := range spec.Predicate {
+				if !poolMetrics.Has(pp.Name) {
+					allErrs = append(allErrs, field.Invalid(idxPath.Child("syncPolicy"), pp.Name, "must include the metrics of predicate policies of spec"))
+				}
+			}
+		}
+
+		if len(pool.Priority) > 0 {
+			allErrs = append(allErrs, validatePriorities(pool.Priority, poolMetrics, idxPath.Child("priority"))...)
+		} else if len(pool.SyncPeriod) > 0 {
+			for _, pp := range spec.Priority {
+				if !poolMetrics.Has(pp.Name) {
+					allErrs = append(allErrs, field.Invalid(idxPath.Child("syncPolicy"), pp.Name, "must include the metrics of priority policies of spec"))
+				}
+			}
+		}
+
+		if len(pool.Balance) > 0 {
+			allErrs = append(allErrs, validateBalances(pool.Balance, poolMetrics, idxPath.Child("balance"))...)
+		} else if len(pool.SyncPeriod) > 0 {
+			for _, bp := range spec.Balance {
+				for _, name := range bp.Metrics {
+					if !poolMetrics.Has(name) {
+						allErrs = append(allErrs, field.Invalid(idxPath.Child("syncPolicy"), name, "must include the metrics of balance policies of spec"))
+					}
+				}
+			}
+		}
+
+		allErrs = append(allErrs, validateHotValues(pool.HotValue, idxPath.Child("hotValue"))...)
+	}
+
+	if spec.Exemptions != nil && spec.Exemptions.PodSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(spec.Exemptions.PodSelector, fldPath.Child("exemptions", "podSelector"))...)
+	}
+
+	profileNames, priorityClassNames := sets.NewString(), sets.NewString()
+	profilesPath := fldPath.Child("profiles")
+	for i, profile := range spec.Profiles {
+		idxPath := profilesPath.Index(i)
+		if profile.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "profile name is required"))
+		} else if profileNames.Has(profile.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), profile.Name))
+		}
+		profileNames.Insert(profile.Name)
+
+		// a PriorityClass selecting more than one profile is ambiguous.
+		for j, name := range profile.PriorityClassNames {
+			if priorityClassNames.Has(name) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("priorityClassNames").Index(j), name))
+			}
+			priorityClassNames.Insert(name)
+		}
+
+		// profiles apply on all nodes, so their metrics must be synced for the nodes not in any
+		// node pool and for every node pool with its own sync policies.
+		allErrs = append(allErrs, validatePredicates(profile.Predicate, syncedMetrics, idxPath.Child("predicate"))...)
+		allErrs = append(allErrs, validatePriorities(profile.Priority, syncedMetrics, idxPath.Child("priority"))...)
+		allErrs = append(allErrs, validateBalances(profile.Balance, syncedMetrics, idxPath.Child("balance"))...)
+		for _, poolName := range sets.StringKeySet(poolSyncedMetrics).List() {
+			poolMetrics := poolSyncedMetrics[poolName]
+			for j, pp := range profile.Predicate {
+				allErrs = append(allErrs, validatePoolSyncedMetric(pp.Name, poolName, poolMetrics, idxPath.Child("predicate").Index(j).Child("name"))...)
+			}
+			for j, pp := range profile.Priority {
+				allErrs = append(allErrs, validatePoolSyncedMetric(pp.Name, poolName, poolMetrics, idxPath.Child("priority").Index(j).Child("name"))...)
+			}
+			for j, bp := range profile.Balance {
+				for k, name := range bp.Metrics {
+					allErrs = append(allErrs, validatePoolSyncedMetric(name, poolName, poolMetrics, idxPath.Child("balance").Index(j).Child("metrics").Index(k))...)
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateSyncPolicies(syncPolicies []policy.SyncPolicy, fldPath *field.Path) (field.ErrorList, sets.String) {
+	allErrs := field.ErrorList{}
+
+	syncedMetrics := sets.NewString()
+	for i, sp := range syncPolicies {
+		idxPath := fldPath.Index(i)
+		allErrs = append(allErrs, validateMetricName(sp.Name, syncedMetrics, idxPath.Child("name"))...)
+		if sp.Period.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("period"), sp.Period.Duration.String(), "must be greater than 0"))
+		}
+		if sp.Query != "" {
+			if _, err := template.New(sp.Name).Parse(sp.Query); err != nil {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("query"), sp.Query, err.Error()))
+			}
+		}
+		if sp.NodeLabel != "" && !labelNameRegexp.MatchString(sp.NodeLabel) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("nodeLabel"), sp.NodeLabel, "must be a valid Prometheus label name"))
+		}
+		if sp.Scale < 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("scale"), sp.Scale, "must be greater than or equal to 0"))
+		}
+	}
+
+	return allErrs, syncedMetrics
+}
+
+func validatePredicates(predicates []policy.PredicatePolicy, syncedMetrics sets.String, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	predicateMetrics := sets.NewString()
+	for i, pp := range predicates {
+		idxPath := fldPath.Index(i)
+		allErrs = append(allErrs, validateMetricName(pp.Name, predicateMetrics, idxPath.Child("name"))...)
+		allErrs = append(allErrs, validateSyncedMetric(pp.Name, syncedMetrics, idxPath.Child("name"))...)
+		if pp.MaxLimitPecent < 0 || pp.MaxLimitPecent > 1 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("maxLimitPecent"), pp.MaxLimitPecent, "must be in the range [0, 1]"))
+		}
+		allErrs = append(allErrs, validateMissingDataPolicy(pp.MissingData, idxPath.Child("missingData"))...)
+		if pp.Relaxation != nil {
+			relaxationPath := idxPath.Child("relaxation")
+			if pp.Relaxation.Step <= 0 {
+				allErrs = append(allErrs, field.Invalid(relaxationPath.Child("step"), pp.Relaxation.Step, "must be greater than 0"))
+			}
+			if pp.Relaxation.Ceiling < pp.MaxLimitPecent || pp.Relaxation.Ceiling > 1 {
+				allErrs = append(allErrs, field.Invalid(relaxationPath.Child("ceiling"), pp.Relaxation.Ceiling, "must be in the range [maxLimitPecent, 1]"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validatePriorities(priorities []policy.PriorityPolicy, syncedMetrics sets.String, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	var totalWeight float64
+	priorityMetrics := sets.NewString()
+	for i, pp := range priorities {
+		idxPath := fldPath.Index(i)
+		allErrs = append(allErrs, validateMetricName(pp.Name, priorityMetrics, idxPath.Child("name"))...)
+		allErrs = append(allErrs, validateSyncedMetric(pp.Name, syncedMetrics, idxPath.Child("name"))...)
+		if pp.Weight < 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("weight"), pp.Weight, "must be greater than or equal to 0"))
+		}
+		allErrs = append(allErrs, validateMissingDataPolicy(pp.MissingData, idxPath.Child("missingData"))...)
+		totalWeight += pp.Weight
+	}
+	if len(priorities) > 0 && totalWeight <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, totalWeight, "sum of weights must be greater than 0"))
+	}
+
+	return allErrs
+}
+
+func validateBalances(balances []policy.BalancePolicy, syncedMetrics sets.String, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	balanceNames := sets.NewString()
+	for i, bp := range balances {
+		idxPath := fldPath.Index(i)
+		if bp.Name == "" {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "balance name is required"))
+		} else if balanceNames.Has(bp.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), bp.Name))
+		}
+		balanceNames.Insert(bp.Name)
+
+		if len(bp.Metrics) < 2 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("metrics"), len(bp.Metrics), "must have at least 2 metrics"))
+		}
+		balanceMetrics := sets.NewString()
+		for j, name := range bp.Metrics {
+			allErrs = append(allErrs, validateMetricName(name, balanceMetrics, idxPath.Child("metrics").Index(j))...)
+			allErrs = append(allErrs, validateSyncedMetric(name, syncedMetrics, idxPath.Child("metrics").Index(j))...)
+		}
+
+		if bp.Weight < 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("weight"), bp.Weight, "must be greater than or equal to 0"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateMetricName(name string, seen sets.String, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if name == "" {
+		allErrs = append(allErrs, field.Required(fldPath, "metric name is required"))
+		return allErrs
+	}
+
+	if seen.Has(name) {
+		allErrs = append(allErrs, field.Duplicate(fldPath, name))
+	}
+	seen.Insert(name)
+
+	return allErrs
+}
+
+func validateSyncedMetric(name string, syncedMetrics sets.String, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if name != "" && !syncedMetrics.Has(name) {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, "must have a matching entry in syncPolicy"))
+	}
+
+	return allErrs
+}
+
+func validatePoolSyncedMetric(name, poolName string, poolSyncedMetrics sets.String, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if name != "" && !poolSyncedMetrics.Has(name) {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, fmt.Sprintf("must have a matching entry in syncPolicy of node pool %s", poolName)))
+	}
+
+	return allErrs
+}
+
+func validateHotValues(hotValues []policy.HotValuePolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, hv := range hotValues {
+		idxPath := fldPath.Index(i)
+		if hv.TimeRange.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("timeRange"), hv.TimeRange.Duration.String(), "must be greater than 0"))
+		}
+		if hv.Count <= 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("count"), hv.Count, "must be greater than 0"))
+		}
+		switch hv.Model {
+		case "", policy.HotValueModelStep, policy.HotValueModelLinear:
+		case policy.HotValueModelExponentialDecay:
+			if hv.HalfLife.Duration <= 0 {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("halfLife"), hv.HalfLife.Duration.String(), "must be greater than 0"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("model"), hv.Model, supportedHotValueModels))
+		}
+	}
+
+	return allErrs
+}
+
+func validateMissingDataPolicy(missingData policy.MissingDataPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !supportedMissingDataPolicies.Has(string(missingData)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath, missingData, supportedMissingDataPolicies.List()[1:]))
+	}
+
+	return allErrs
+}
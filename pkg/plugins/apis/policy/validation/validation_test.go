@@ -0,0 +1,354 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+func newValidPolicy() *policy.DynamicSchedulerPolicy {
+	return &policy.DynamicSchedulerPolicy{
+		Spec: policy.PolicySpec{
+			SyncPeriod: []policy.SyncPolicy{
+				{Name: "cpu_usage_avg_5m", Period: metav1.Duration{Duration: 3 * time.Minute}},
+				{Name: "mem_usage_avg_5m", Period: metav1.Duration{Duration: 3 * time.Minute}},
+			},
+			Predicate: []policy.PredicatePolicy{
+				{Name: "cpu_usage_avg_5m", MaxLimitPecent: 0.65},
+				{Name: "mem_usage_avg_5m", MaxLimitPecent: 0.65},
+			},
+			Priority: []policy.PriorityPolicy{
+				{Name: "cpu_usage_avg_5m", Weight: 0.2},
+				{Name: "mem_usage_avg_5m", Weight: 0.2},
+			},
+			HotValue: []policy.HotValuePolicy{
+				{TimeRange: metav1.Duration{Duration: 5 * time.Minute}, Count: 5},
+			},
+		},
+	}
+}
+
+func TestValidateDynamicSchedulerPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(p *policy.DynamicSchedulerPolicy)
+		want   field.ErrorList
+	}{
+		{
+			name:   "valid policy",
+			modify: func(p *policy.DynamicSchedulerPolicy) {},
+			want:   field.ErrorList{},
+		},
+		{
+			name: "non-positive sync period",
+			modify: func(p *policy.DynamicSchedulerPolicy) {
+				p.Spec.SyncPeriod[0].Period.Duration = 0
+			},
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "syncPolicy").Index(0).Child("period"), "0s", ""),
+			},
+		},
+		{
+			name: "invalid query template",
+			modify: func(p *policy.DynamicSchedulerPolicy) {
+				p.Spec.SyncPeriod[0].Query = "{{ .Metric }"
+			},
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "syncPolicy").Index(0).Child("query"), "{{ .Metric }", ""),
+			},
+		},
+		{
+			name: "invalid node label and negative scale",
+			modify: func(p *policy.DynamicSchedulerPolicy) {
+				p.Spec.SyncPeriod[0].NodeLabel = "node-name"
+				p.Spec.SyncPeriod[0].Scale = -1
+			},
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "syncPolicy").Index(0).Child("nodeLabel"), "node-name", ""),
+				field.Invalid(field.NewPath("spec", "syncPolicy").Index(0).Child("scale"), -1, ""),
+			},
+		},
+		{
+			name: "duplicate sync policy",
+			modify: func(p *policy.DynamicSchedulerPolicy) {
+				p.Spec.SyncPeriod[1].Name = "cpu_usage_avg_5m"
+			},
+			want: field.ErrorList{
+				field.Duplicate(field.NewPath("spec", "syncPolicy").Index(1).Child("name"), "cpu_usage_avg_5m"),
+				field.Invalid(field.NewPath("spec", "predicate").Index(1).Child("name"), "mem_usage_avg_5m", ""),
+				field.Invalid(field.NewPath("spec", "priority").Index(1).Child("name"), "mem_usage_avg_5m", ""),
+			},
+		},
+		{
+			name: "max limit percent out of range",
+			modify: func(p *policy.DynamicSchedulerPolicy) {
+				p.Spec.Predicate[0].MaxLimitPecent = 65
+			},
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "predicate").Index(0).Child("maxLimitPecent"), 65, ""),
+			},
+		},
+		{
+			name: "invalid relaxation",
+			modify: func(p *policy.DynamicSchedulerPolicy) {
+				p.Spec.Predicate[0].Relaxation = &policy.ThresholdRelaxation{Step: 0, Ceiling: 0.8}
+				p.Spec.Predicate[1].Relaxation = &policy.ThresholdRelaxation{Step: 0.05, Ceiling: 0.6}
+			},
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "predicate").Index(0).Child("relaxation", "step"), 0, ""),
+				field.Invalid(field.NewPath("spec", "predicate").Index(1).Child("relaxation", "ceiling"), 0.6, ""),
+			},
+		},
+		{
+			name: "predicate without sync policy",
+			modify: func(p *policy.DynamicSchedulerPolicy) {
+				p.Spec.Predicate[0].Name = "cpu_usage_max_avg_1h"
+			},
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "predicate").Index(0).Child("name"), "cpu_usage_max_avg_1h", ""),
+			},
+		},
+		{
+			name: "negative weight",
+			modify: func(p *policy.DynamicSchedulerPolicy) {
+				p.Spec.Priority[0].Weight = -0.2
+			},
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "priority").Index(0).Child("weight"), -0.2, ""),
+				field.Invalid(field.NewPath("spec", "priority"), 0, ""),
+			},
+		},
+		{
+			name: "empty priority name",
+			modify: func(p *policy.DynamicSchedulerPolicy) {
+				p.Spec.Priority[1].Name = ""
+			},
+			want: field.ErrorList{
+				field.Required(field.NewPath("spec", "priority").Index(1).Child("name"), ""),
+			},
+		},
+		{
+			name: "unsupported missing data policy",
+			modify: func(p *policy.DynamicSchedulerPolicy) {
+				p.Spec.Predicate[0].MissingData = policy.MissingDataTreatAsOverloaded
+				p.Spec.Priority[0].MissingData = "Ignore"
+			},
+			want: field.ErrorList{
+				field.NotSupported(field.NewPath("spec", "priority").Index(0).Child("missingData"), "Ignore", nil),
+			},
+		},
+		{
+			name: "invalid profiles",
+			modify: func(p *policy.DynamicSchedulerPolicy) {
+				p.Spec.Profiles = []policy.PolicyProfile{
+					{
+						Name:               "latency-sensitive",
+						PriorityClassNames: []string{"high-priority"},
+						Predicate:          []policy.PredicatePolicy{{Name: "cpu_usage_avg_5m", MaxLimitPecent: 0.5}},
+					},
+					{
+						Name:               "latency-sensitive",
+						PriorityClassNames: []string{"high-priority"},
+						Predicate:          []policy.PredicatePolicy{{Name: "cpu_usage_max_avg_1h", MaxLimitPecent: 0.9}},
+					},
+				}
+			},
+			want: field.ErrorList{
+				field.Duplicate(field.NewPath("spec", "profiles").Index(1).Child("name"), "latency-sensitive"),
+				field.Duplicate(field.NewPath("spec", "profiles").Index(1).Child("priorityClassNames").Index(0), "high-priority"),
+				field.Invalid(field.NewPath("spec", "profiles").Index(1).Child("predicate").Index(0).Child("name"), "cpu_usage_max_avg_1h", ""),
+			},
+		},
+		{
+			name: "invalid node pools",
+			modify: func(p *policy.DynamicSchedulerPolicy) {
+				p.Spec.NodePools = []policy.NodePoolPolicy{
+					{
+						Name: "gpu",
+						NodeSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "gpu", Operator: "Has"}},
+						},
+					},
+					{
+						Name:         "memory-optimized",
+						NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "memory"}},
+						SyncPeriod: []policy.SyncPolicy{
+							{Name: "mem_usage_avg_5m", Period: metav1.Duration{Duration: time.Minute}},
+						},
+						Predicate: []policy.PredicatePolicy{{Name: "mem_usage_avg_5m", MaxLimitPecent: 0.8}},
+					},
+					{Name: "general"},
+				}
+			},
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "nodePools").Index(0).Child("nodeSelector", "matchExpressions").Index(0).Child("operator"), "Has", ""),
+				field.Invalid(field.NewPath("spec", "nodePools").Index(1).Child("syncPolicy"), "cpu_usage_avg_5m", ""),
+				field.Required(field.NewPath("spec", "nodePools").Index(2).Child("nodeSelector"), ""),
+			},
+		},
+		{
+			name: "profile metrics not synced for every node pool",
+			modify: func(p *policy.DynamicSchedulerPolicy) {
+				p.Spec.NodePools = []policy.NodePoolPolicy{
+					{
+						Name:         "gpu",
+						NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "gpu"}},
+						SyncPeriod: []policy.SyncPolicy{
+							{Name: "cpu_usage_avg_5m", Period: metav1.Duration{Duration: time.Minute}},
+							{Name: "mem_usage_avg_5m", Period: metav1.Duration{Duration: time.Minute}},
+							{Name: "gpu_usage_avg_5m", Period: metav1.Duration{Duration: time.Minute}},
+						},
+					},
+					{
+						Name:         "memory-optimized",
+						NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "memory"}},
+						SyncPeriod: []policy.SyncPolicy{
+							{Name: "cpu_usage_avg_5m", Period: metav1.Duration{Duration: time.Minute}},
+							{Name: "mem_usage_avg_5m", Period: metav1.Duration{Duration: time.Minute}},
+						},
+					},
+				}
+				p.Spec.Profiles = []policy.PolicyProfile{
+					{
+						Name:      "batch",
+						Predicate: []policy.PredicatePolicy{{Name: "gpu_usage_avg_5m", MaxLimitPecent: 0.9}},
+						Priority:  []policy.PriorityPolicy{{Name: "mem_usage_avg_5m", Weight: 1}},
+					},
+					{
+						Name:      "latency-sensitive",
+						Predicate: []policy.PredicatePolicy{{Name: "cpu_usage_avg_5m", MaxLimitPecent: 0.5}},
+					},
+				}
+			},
+			want: field.ErrorList{
+				// the metric synced only for gpu pool is missing on nodes not in any pool and on
+				// memory-optimized pool.
+				field.Invalid(field.NewPath("spec", "profiles").Index(0).Child("predicate").Index(0).Child("name"), "gpu_usage_avg_5m", ""),
+				field.Invalid(field.NewPath("spec", "profiles").Index(0).Child("predicate").Index(0).Child("name"), "gpu_usage_avg_5m", ""),
+			},
+		},
+		{
+			name: "invalid profile balance policies",
+			modify: func(p *policy.DynamicSchedulerPolicy) {
+				p.Spec.SyncPeriod = append(p.Spec.SyncPeriod, policy.SyncPolicy{Name: "gpu_usage_avg_5m", Period: metav1.Duration{Duration: time.Minute}})
+				p.Spec.NodePools = []policy.NodePoolPolicy{
+					{
+						Name:         "memory-optimized",
+						NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "memory"}},
+						SyncPeriod: []policy.SyncPolicy{
+							{Name: "cpu_usage_avg_5m", Period: metav1.Duration{Duration: time.Minute}},
+							{Name: "mem_usage_avg_5m", Period: metav1.Duration{Duration: time.Minute}},
+						},
+					},
+				}
+				p.Spec.Profiles = []policy.PolicyProfile{
+					{
+						Name: "batch",
+						Balance: []policy.BalancePolicy{
+							{Name: "cpu-gpu", Metrics: []string{"cpu_usage_avg_5m", "gpu_usage_avg_5m"}, Weight: 1},
+							{Name: "cpu-io", Metrics: []string{"cpu_usage_avg_5m", "io_usage_avg_5m"}, Weight: 1},
+						},
+					},
+				}
+			},
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "profiles").Index(0).Child("balance").Index(1).Child("metrics").Index(1), "io_usage_avg_5m", ""),
+				// the metric synced by spec is missing on memory-optimized pool.
+				field.Invalid(field.NewPath("spec", "profiles").Index(0).Child("balance").Index(0).Child("metrics").Index(1), "gpu_usage_avg_5m", ""),
+				field.Invalid(field.NewPath("spec", "profiles").Index(0).Child("balance").Index(1).Child("metrics").Index(1), "io_usage_avg_5m", ""),
+			},
+		},
+		{
+			name: "invalid node pool balance and hot value policies",
+			modify: func(p *policy.DynamicSchedulerPolicy) {
+				p.Spec.NodePools = []policy.NodePoolPolicy{
+					{
+						Name:         "memory-optimized",
+						NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "memory"}},
+						Balance: []policy.BalancePolicy{
+							{Name: "cpu-mem", Metrics: []string{"cpu_usage_avg_5m", "mem_usage_avg_5m"}, Weight: 1},
+							{Name: "cpu-io", Metrics: []string{"cpu_usage_avg_5m", "io_usage_avg_5m"}, Weight: 1},
+						},
+						HotValue: []policy.HotValuePolicy{{TimeRange: metav1.Duration{Duration: time.Minute}}},
+					},
+				}
+			},
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "nodePools").Index(0).Child("balance").Index(1).Child("metrics").Index(1), "io_usage_avg_5m", ""),
+				field.Invalid(field.NewPath("spec", "nodePools").Index(0).Child("hotValue").Index(0).Child("count"), 0, ""),
+			},
+		},
+		{
+			name: "invalid balance policies",
+			modify: func(p *policy.DynamicSchedulerPolicy) {
+				p.Spec.Balance = []policy.BalancePolicy{
+					{Name: "cpu-mem", Metrics: []string{"cpu_usage_avg_5m", "mem_usage_avg_5m"}, Weight: 1},
+					{Name: "cpu-mem", Metrics: []string{"cpu_usage_avg_5m"}, Weight: -1},
+					{Name: "cpu-io", Metrics: []string{"cpu_usage_avg_5m", "io_usage_avg_5m"}, Weight: 1},
+				}
+			},
+			want: field.ErrorList{
+				field.Duplicate(field.NewPath("spec", "balance").Index(1).Child("name"), "cpu-mem"),
+				field.Invalid(field.NewPath("spec", "balance").Index(1).Child("metrics"), 1, ""),
+				field.Invalid(field.NewPath("spec", "balance").Index(1).Child("weight"), -1, ""),
+				field.Invalid(field.NewPath("spec", "balance").Index(2).Child("metrics").Index(1), "io_usage_avg_5m", ""),
+			},
+		},
+		{
+			name: "invalid exemption pod selector",
+			modify: func(p *policy.DynamicSchedulerPolicy) {
+				p.Spec.Exemptions = &policy.ExemptionRules{
+					PodSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "critical", Operator: metav1.LabelSelectorOpIn}},
+					},
+				}
+			},
+			want: field.ErrorList{
+				field.Required(field.NewPath("spec", "exemptions", "podSelector", "matchExpressions").Index(0).Child("values"), ""),
+			},
+		},
+		{
+			name: "invalid hot value models",
+			modify: func(p *policy.DynamicSchedulerPolicy) {
+				p.Spec.HotValue = []policy.HotValuePolicy{
+					{TimeRange: metav1.Duration{Duration: 5 * time.Minute}, Count: 5, Model: policy.HotValueModelExponentialDecay},
+					{TimeRange: metav1.Duration{Duration: 5 * time.Minute}, Count: 5, Model: "Quadratic"},
+				}
+			},
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "hotValue").Index(0).Child("halfLife"), "0s", ""),
+				field.NotSupported(field.NewPath("spec", "hotValue").Index(1).Child("model"), "Quadratic", nil),
+			},
+		},
+		{
+			name: "zero hot value count",
+			modify: func(p *policy.DynamicSchedulerPolicy) {
+				p.Spec.HotValue[0].Count = 0
+			},
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "hotValue").Index(0).Child("count"), 0, ""),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newValidPolicy()
+			tt.modify(p)
+
+			got := ValidateDynamicSchedulerPolicy(p)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ValidateDynamicSchedulerPolicy() got %d errors: %v, want %d errors", len(got), got, len(tt.want))
+			}
+			for i := range got {
+				if got[i].Type != tt.want[i].Type || got[i].Field != tt.want[i].Field {
+					t.Errorf("ValidateDynamicSchedulerPolicy() error[%d] = %v, want %s %s", i, got[i], tt.want[i].Type, tt.want[i].Field)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package dynamic
+
+import (
+	"encoding/json"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/api/v1/resource"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/gocrane/crane-scheduler/pkg/utils"
+)
+
+const (
+	// ExpectedUsageAnnotationKey is the key of pod annotation with the expected usage of pod in
+	// JSON, such as {"cpu":"500m","memory":"1Gi"}. It takes precedence over the historical usage
+	// of workload and pod requests. It is set by users on pod creation.
+	ExpectedUsageAnnotationKey = "scheduler.crane.io/expected-usage"
+	// WorkloadUsageAnnotationKey is the key of the annotation of ReplicaSet or StatefulSet with
+	// the historical usage per pod of the workload in the same format, such as the percentile
+	// recommended by a usage recommender from the usage of its pods. The annotations of a
+	// Deployment are copied to its ReplicaSets by the deployment controller.
+	WorkloadUsageAnnotationKey = "scheduler.crane.io/workload-usage"
+
+	podUsageStateKey framework.StateKey = Name + "/pod-usage"
+)
+
+// podUsageState caches the expected usage of pod in a scheduling cycle.
+type podUsageState struct {
+	usage v1.ResourceList
+}
+
+// Clone returns the state itself, since it is never modified.
+func (s *podUsageState) Clone() framework.StateData {
+	return s
+}
+
+// parseUsage parses the usage in annotations with key, and returns false if it is not found or
+// illegal.
+func parseUsage(annotations map[string]string, key string) (v1.ResourceList, bool, error) {
+	value, ok := annotations[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	usage := v1.ResourceList{}
+	if err := json.Unmarshal([]byte(value), &usage); err != nil {
+		return nil, false, err
+	}
+
+	return usage, true, nil
+}
+
+// estimateUsage returns the expected usage of pod from its annotation, the historical usage
+// of its workload, or its requests, in the order of precedence.
+func (ds *DynamicScheduler) estimateUsage(pod *v1.Pod) v1.ResourceList {
+	usage, ok, err := parseUsage(pod.Annotations, ExpectedUsageAnnotationKey)
+	if ok {
+		return usage
+	}
+	if err != nil {
+		klog.Warningf("[crane] illegel expected usage of pod[%s/%s], ignore it: %v", pod.Namespace, pod.Name, err)
+	}
+
+	if usage, ok := ds.getWorkloadUsage(pod); ok {
+		return usage
+	}
+
+	requests, _ := resource.PodRequestsAndLimits(pod)
+	return requests
+}
+
+// getWorkloadUsage returns the historical usage per pod of the ReplicaSet or StatefulSet which
+// pod belongs to.
+func (ds *DynamicScheduler) getWorkloadUsage(pod *v1.Pod) (v1.ResourceList, bool) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return nil, false
+	}
+
+	var workload metav1.Object
+	var err error
+	switch {
+	case owner.Kind == "ReplicaSet" && ds.replicaSetLister != nil:
+		workload, err = ds.replicaSetLister.ReplicaSets(pod.Namespace).Get(owner.Name)
+	case owner.Kind == "StatefulSet" && ds.statefulSetLister != nil:
+		workload, err = ds.statefulSetLister.StatefulSets(pod.Namespace).Get(owner.Name)
+	default:
+		return nil, false
+	}
+	if err != nil {
+		klog.V(4).Infof("[crane] failed to get %s[%s/%s] of pod[%s]: %v", owner.Kind, pod.Namespace, owner.Name, pod.Name, err)
+		return nil, false
+	}
+
+	usage, ok, err := parseUsage(workload.GetAnnotations(), WorkloadUsageAnnotationKey)
+	if err != nil {
+		klog.Warningf("[crane] illegel workload usage of %s[%s/%s], ignore it: %v", owner.Kind, pod.Namespace, owner.Name, err)
+	}
+
+	return usage, ok
+}
+
+// getPodUsage returns the expected usage of pod, which is estimated once per scheduling cycle.
+func (ds *DynamicScheduler) getPodUsage(state *framework.CycleState, pod *v1.Pod) v1.ResourceList {
+	if data, err := state.Read(podUsageStateKey); err == nil {
+		if s, ok := data.(*podUsageState); ok {
+			return s.usage
+		}
+	}
+
+	usage := ds.estimateUsage(pod)
+	state.Write(podUsageStateKey, &podUsageState{usage: usage})
+
+	return usage
+}
+
+// projectedLoadSource adds the expected usage of the incoming pod to the usage of node, so
+// that nodes are filtered and scored by the usage after placement.
+type projectedLoadSource struct {
+	loadSource
+	podUsage v1.ResourceList
+	// capacity is the capacity of node, which the usage of node is relative to.
+	capacity v1.ResourceList
+}
+
+func (s projectedLoadSource) getLoad(key string) (float64, time.Time, error) {
+	value, updateTime, err := s.loadSource.getLoad(key)
+	if err != nil {
+		return 0, updateTime, err
+	}
+
+	return value + s.podUsageRatio(key), updateTime, nil
+}
+
+// podUsageRatio returns the ratio of pod usage to node capacity of the resource which metric
+// refers to, or 0 if the metric does not refer to any resource. Capacity rather than allocatable
+// is used, since the usage of node synced by node annotator is relative to capacity.
+func (s projectedLoadSource) podUsageRatio(metricName string) float64 {
+	resourceName, ok := utils.GetResourceNameOfMetric(metricName)
+	if !ok {
+		return 0
+	}
+
+	usage, ok := s.podUsage[resourceName]
+	if !ok {
+		return 0
+	}
+
+	capacity, ok := s.capacity[resourceName]
+	if !ok || capacity.IsZero() {
+		return 0
+	}
+
+	return float64(usage.MilliValue()) / float64(capacity.MilliValue())
+}
@@ -0,0 +1,151 @@
+package dynamic
+
+import (
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/gocrane/crane-scheduler/pkg/utils"
+)
+
+func TestProjectedLoadSource(t *testing.T) {
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("1"),
+						v1.ResourceMemory: resource.MustParse("2Gi"),
+					},
+				},
+			}},
+		},
+	}
+	annotatedPod := pod.DeepCopy()
+	annotatedPod.Annotations = map[string]string{ExpectedUsageAnnotationKey: `{"cpu":"2"}`}
+
+	// the pod of a ReplicaSet with historical usage, and that of a StatefulSet without it.
+	replicaSetPod := pod.DeepCopy()
+	replicaSetPod.Namespace = "default"
+	replicaSetPod.OwnerReferences = []metav1.OwnerReference{newControllerRef("ReplicaSet", "web-5d8f")}
+	statefulSetPod := replicaSetPod.DeepCopy()
+	statefulSetPod.OwnerReferences = []metav1.OwnerReference{newControllerRef("StatefulSet", "db")}
+	annotatedReplicaSetPod := replicaSetPod.DeepCopy()
+	annotatedReplicaSetPod.Annotations = annotatedPod.Annotations
+
+	replicaSets := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	replicaSets.Add(&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Namespace:   "default",
+		Name:        "web-5d8f",
+		Annotations: map[string]string{WorkloadUsageAnnotationKey: `{"cpu":"3","memory":"4Gi"}`},
+	}})
+	statefulSets := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	statefulSets.Add(&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "db"}})
+
+	ds := &DynamicScheduler{
+		replicaSetLister:  appslisters.NewReplicaSetLister(replicaSets),
+		statefulSetLister: appslisters.NewStatefulSetLister(statefulSets),
+	}
+
+	// the usage of node is relative to capacity, which the usage of pod is relative to as well.
+	capacity := v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("4"),
+		v1.ResourceMemory: resource.MustParse("8Gi"),
+	}
+
+	timestamp := utils.FormatTimestamp(time.Now())
+	anno := annotationLoadSource{
+		"cpu_usage_avg_5m": "0.50000," + timestamp,
+		"mem_usage_avg_5m": "0.50000," + timestamp,
+		NodeHotValue:       "1," + timestamp,
+	}
+
+	tests := []struct {
+		name string
+		pod  *v1.Pod
+		want map[string]float64
+	}{
+		{
+			name: "requests",
+			pod:  pod,
+			want: map[string]float64{"cpu_usage_avg_5m": 0.75, "mem_usage_avg_5m": 0.75, NodeHotValue: 1},
+		},
+		{
+			name: "expected usage annotation",
+			pod:  annotatedPod,
+			want: map[string]float64{"cpu_usage_avg_5m": 1, "mem_usage_avg_5m": 0.5, NodeHotValue: 1},
+		},
+		{
+			name: "historical usage of workload",
+			pod:  replicaSetPod,
+			want: map[string]float64{"cpu_usage_avg_5m": 1.25, "mem_usage_avg_5m": 1, NodeHotValue: 1},
+		},
+		{
+			name: "expected usage annotation over historical usage of workload",
+			pod:  annotatedReplicaSetPod,
+			want: map[string]float64{"cpu_usage_avg_5m": 1, "mem_usage_avg_5m": 0.5, NodeHotValue: 1},
+		},
+		{
+			name: "workload without historical usage",
+			pod:  statefulSetPod,
+			want: map[string]float64{"cpu_usage_avg_5m": 0.75, "mem_usage_avg_5m": 0.75, NodeHotValue: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := projectedLoadSource{
+				loadSource: anno,
+				podUsage:   ds.getPodUsage(framework.NewCycleState(), tt.pod),
+				capacity:   capacity,
+			}
+
+			for key, want := range tt.want {
+				got, _, err := source.getLoad(key)
+				if err != nil {
+					t.Fatalf("getLoad(%s) error = %v", key, err)
+				}
+				if got != want {
+					t.Errorf("getLoad(%s) = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func newControllerRef(kind, name string) metav1.OwnerReference {
+	controller := true
+	return metav1.OwnerReference{Kind: kind, Name: name, Controller: &controller}
+}
+
+func TestGetLoadSourceRelativeToCapacity(t *testing.T) {
+	timestamp := utils.FormatTimestamp(time.Now())
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "node",
+			Annotations: map[string]string{"cpu_usage_avg_5m": "0.50000," + timestamp},
+		},
+		Status: v1.NodeStatus{
+			Capacity:    v1.ResourceList{v1.ResourceCPU: resource.MustParse("8")},
+			Allocatable: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4")},
+		},
+	}
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{ExpectedUsageAnnotationKey: `{"cpu":"2"}`}}}
+
+	ds := &DynamicScheduler{estimatePodUsage: true}
+	got, _, err := ds.getLoadSource(framework.NewCycleState(), pod, node).getLoad("cpu_usage_avg_5m")
+	if err != nil {
+		t.Fatalf("getLoad() error = %v", err)
+	}
+	// 2 cores of the pod add 0.25 to the usage of 8 cores, rather than 0.5 of allocatable 4 cores.
+	if got != 0.75 {
+		t.Errorf("getLoad() = %v, want 0.75", got)
+	}
+}
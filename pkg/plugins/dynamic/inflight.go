@@ -0,0 +1,137 @@
+package dynamic
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/helper"
+)
+
+const (
+	// DefaultInFlightBindingTTL is how long the in-flight bindings are kept, which is long
+	// enough for the node annotator to publish the hot value counting them.
+	DefaultInFlightBindingTTL = 10 * time.Minute
+)
+
+// inFlightTracker records the pods assigned to each node by this scheduler recently, which
+// are not yet counted by the hot value published by the node annotator.
+type inFlightTracker struct {
+	lock sync.Mutex
+	// nodes records the reserve time of pods assigned to each node.
+	nodes map[string]map[types.UID]time.Time
+	ttl   time.Duration
+}
+
+func newInFlightTracker(ttl time.Duration) *inFlightTracker {
+	return &inFlightTracker{
+		nodes: map[string]map[types.UID]time.Time{},
+		ttl:   ttl,
+	}
+}
+
+func (t *inFlightTracker) reserve(nodeName string, uid types.UID, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	pods, ok := t.nodes[nodeName]
+	if !ok {
+		pods = map[types.UID]time.Time{}
+		t.nodes[nodeName] = pods
+	}
+	pods[uid] = now
+}
+
+func (t *inFlightTracker) unreserve(nodeName string, uid types.UID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if pods, ok := t.nodes[nodeName]; ok {
+		delete(pods, uid)
+		if len(pods) == 0 {
+			delete(t.nodes, nodeName)
+		}
+	}
+}
+
+// hotValue returns the hot value of the pods assigned to node after since, according to
+// hotValuePolicies, which is computed by the same models as the node annotator. With the Step
+// model, every count in-flight pods add 1, since the remainder of the bindings counted by the
+// published hot value is unknown.
+func (t *inFlightTracker) hotValue(nodeName string, hotValuePolicies []policy.HotValuePolicy, since, now time.Time) float64 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	pods := t.nodes[nodeName]
+	if len(pods) == 0 {
+		return 0
+	}
+
+	var value float64
+	for _, hv := range hotValuePolicies {
+		var weight float64
+		for _, reserveTime := range pods {
+			if reserveTime.After(since) {
+				weight += helper.HotValueBindingWeight(hv, now.Sub(reserveTime))
+			}
+		}
+
+		value += helper.HotValue(hv, weight)
+	}
+
+	return value
+}
+
+// gc forgets the pods assigned earlier than ttl.
+func (t *inFlightTracker) gc() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	timeline := time.Now().Add(-t.ttl)
+	for nodeName, pods := range t.nodes {
+		for uid, reserveTime := range pods {
+			if reserveTime.Before(timeline) {
+				delete(pods, uid)
+			}
+		}
+		if len(pods) == 0 {
+			delete(t.nodes, nodeName)
+		}
+	}
+}
+
+// Reserve records the pod assigned to node, so that the following pods are spread before
+// the hot value of node is updated. It records the shadow rejections of the scheduling cycle
+// as well.
+func (ds *DynamicScheduler) Reserve(ctx context.Context, state *framework.CycleState, p *v1.Pod, nodeName string) *framework.Status {
+	ds.recordShadowRejectionEvent(state, p)
+
+	if ds.inFlight != nil {
+		ds.inFlight.reserve(nodeName, p.UID, time.Now())
+	}
+
+	return nil
+}
+
+// Unreserve forgets the pod which fails to be bound to node.
+func (ds *DynamicScheduler) Unreserve(ctx context.Context, state *framework.CycleState, p *v1.Pod, nodeName string) {
+	if ds.inFlight != nil {
+		ds.inFlight.unreserve(nodeName, p.UID)
+	}
+}
+
+// getInFlightHotValue returns the hot value of pods assigned to node by this scheduler after
+// the hot value of node was updated at hotValueTime. Pods assigned up to clock skew tolerance
+// earlier are counted as well, since the node annotator observes bindings with a delay.
+func (ds *DynamicScheduler) getInFlightHotValue(nodeName string, hotValuePolicies []policy.HotValuePolicy, hotValueTime time.Time) float64 {
+	if ds.inFlight == nil {
+		return 0
+	}
+
+	return ds.inFlight.hotValue(nodeName, hotValuePolicies, hotValueTime.Add(-ds.clockSkewTolerance), time.Now())
+}
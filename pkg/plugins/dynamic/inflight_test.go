@@ -0,0 +1,115 @@
+package dynamic
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+func TestInFlightHotValue(t *testing.T) {
+	hotValuePolicies := []policy.HotValuePolicy{
+		{TimeRange: metav1.Duration{Duration: 5 * time.Minute}, Count: 2},
+		{TimeRange: metav1.Duration{Duration: time.Minute}, Count: 1},
+	}
+
+	ds := &DynamicScheduler{inFlight: newInFlightTracker(DefaultInFlightBindingTTL), clockSkewTolerance: time.Minute}
+	for i, name := range []string{"pod1", "pod2", "pod3"} {
+		pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name)}}
+		if status := ds.Reserve(context.TODO(), nil, pod, "node1"); !status.IsSuccess() {
+			t.Fatalf("Reserve() = %v", status)
+		}
+		if i == 2 {
+			// pod3 fails to be bound.
+			ds.Unreserve(context.TODO(), nil, pod, "node1")
+		}
+	}
+
+	now := time.Now()
+	tests := []struct {
+		name         string
+		nodeName     string
+		hotValueTime time.Time
+		want         float64
+	}{
+		{name: "no hot value", nodeName: "node1", want: 2./2 + 2./1},
+		{name: "hot value updated before", nodeName: "node1", hotValueTime: now.Add(-time.Minute), want: 2./2 + 2./1},
+		{name: "hot value updated after", nodeName: "node1", hotValueTime: now.Add(2 * time.Minute), want: 0},
+		{name: "other node", nodeName: "node2", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ds.getInFlightHotValue(tt.nodeName, hotValuePolicies, tt.hotValueTime); got != tt.want {
+				t.Errorf("getInFlightHotValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// pods out of the time range of a policy are not counted by it.
+	if got := ds.inFlight.hotValue("node1", hotValuePolicies, time.Time{}, now.Add(2*time.Minute)); got != 2./2 {
+		t.Errorf("hotValue() = %v two minutes later, want %v", got, 2./2)
+	}
+
+	ds.inFlight.ttl = 0
+	ds.inFlight.gc()
+	if len(ds.inFlight.nodes) != 0 {
+		t.Errorf("in-flight pods are not recycled: %v", ds.inFlight.nodes)
+	}
+}
+
+func TestInFlightHotValueModels(t *testing.T) {
+	now := time.Now()
+
+	// the same bindings as TestHotValueModels of node annotator: 4 recent pods, and one in the
+	// middle of the time range.
+	tracker := newInFlightTracker(DefaultInFlightBindingTTL)
+	for i := 0; i < 4; i++ {
+		tracker.reserve("node1", types.UID(fmt.Sprintf("pod%d", i)), now)
+	}
+	tracker.reserve("node1", "pod4", now.Add(-150*time.Second))
+
+	fiveMinutes := metav1.Duration{Duration: 5 * time.Minute}
+	tests := []struct {
+		name   string
+		policy policy.HotValuePolicy
+		want   float64
+	}{
+		{
+			name:   "step",
+			policy: policy.HotValuePolicy{TimeRange: fiveMinutes, Count: 10},
+			want:   0,
+		},
+		{
+			name:   "step reaching count",
+			policy: policy.HotValuePolicy{TimeRange: fiveMinutes, Count: 2},
+			want:   2,
+		},
+		{
+			name:   "linear",
+			policy: policy.HotValuePolicy{TimeRange: fiveMinutes, Count: 10, Model: policy.HotValueModelLinear},
+			want:   0.45,
+		},
+		{
+			name: "exponential decay",
+			policy: policy.HotValuePolicy{TimeRange: fiveMinutes, Count: 10, Model: policy.HotValueModelExponentialDecay,
+				HalfLife: metav1.Duration{Duration: 150 * time.Second}},
+			want: 0.45,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tracker.hotValue("node1", []policy.HotValuePolicy{tt.policy}, time.Time{}, now); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("hotValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package dynamic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/nodeload/v1alpha1"
+	"github.com/gocrane/crane-scheduler/pkg/utils"
+)
+
+// loadSource provides the load data of a node.
+type loadSource interface {
+	// getLoad returns the value of metric and the time when it was updated.
+	getLoad(key string) (float64, time.Time, error)
+}
+
+// annotationLoadSource reads load data from legacy node annotations in the form of "value,timestamp".
+type annotationLoadSource map[string]string
+
+func (s annotationLoadSource) getLoad(key string) (float64, time.Time, error) {
+	return getAnnotationLoad(s, key, nil)
+}
+
+// legacyTimestampLoadSource reads load data from node annotations like annotationLoadSource,
+// but the timestamps in utils.TimeFormat are local time, which are written by node annotators
+// of earlier versions.
+type legacyTimestampLoadSource map[string]string
+
+func (s legacyTimestampLoadSource) getLoad(key string) (float64, time.Time, error) {
+	return getAnnotationLoad(s, key, utils.GetLocation())
+}
+
+func getAnnotationLoad(anno map[string]string, key string, legacyLocation *time.Location) (float64, time.Time, error) {
+	usedstr, ok := anno[key]
+	if !ok {
+		return 0, time.Time{}, fmt.Errorf("key[%s] not found", key)
+	}
+
+	usedSlice := strings.Split(usedstr, ",")
+	if len(usedSlice) != 2 {
+		return 0, time.Time{}, fmt.Errorf("illegel value: %s", usedstr)
+	}
+
+	updateTime, err := parseTimestamp(usedSlice[1], legacyLocation)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	value, err := strconv.ParseFloat(usedSlice[0], 64)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to parse float[%s]", usedSlice[0])
+	}
+
+	return value, updateTime, nil
+}
+
+// structuredLoadSource reads load data from NodeLoad object or the JSON encoded annotation.
+type structuredLoadSource struct {
+	// from describes where the data comes from, used in error messages.
+	from     string
+	metrics  map[string]v1alpha1.MetricValue
+	hotValue *v1alpha1.MetricValue
+}
+
+func newNodeLoadSource(nodeLoad *v1alpha1.NodeLoad) structuredLoadSource {
+	return structuredLoadSource{from: "NodeLoad", metrics: nodeLoad.Metrics, hotValue: nodeLoad.HotValue}
+}
+
+// newJSONAnnotationLoadSource parses the JSON encoded load annotation, it returns false
+// if the annotation does not exist.
+func newJSONAnnotationLoadSource(anno map[string]string) (structuredLoadSource, bool, error) {
+	value, ok := anno[v1alpha1.LoadAnnotationKey]
+	if !ok {
+		return structuredLoadSource{}, false, nil
+	}
+
+	load := v1alpha1.LoadAnnotation{}
+	if err := json.Unmarshal([]byte(value), &load); err != nil {
+		return structuredLoadSource{}, false, fmt.Errorf("illegel value of annotation %s: %v", v1alpha1.LoadAnnotationKey, err)
+	}
+
+	return structuredLoadSource{from: "annotation " + v1alpha1.LoadAnnotationKey, metrics: load.Metrics, hotValue: load.HotValue}, true, nil
+}
+
+func (s structuredLoadSource) getLoad(key string) (float64, time.Time, error) {
+	if key == NodeHotValue {
+		if s.hotValue == nil {
+			return 0, time.Time{}, fmt.Errorf("hot value not found in %s", s.from)
+		}
+		return s.hotValue.Value, s.hotValue.Timestamp.Time, nil
+	}
+
+	metric, ok := s.metrics[key]
+	if !ok {
+		return 0, time.Time{}, fmt.Errorf("metric[%s] not found in %s", key, s.from)
+	}
+
+	return metric.Value, metric.Timestamp.Time, nil
+}
+
+// multiLoadSource reads load data from all sources, and returns the most recent one.
+type multiLoadSource []loadSource
+
+func (s multiLoadSource) getLoad(key string) (float64, time.Time, error) {
+	var value float64
+	var updateTime time.Time
+	var errs []string
+
+	for _, source := range s {
+		v, t, err := source.getLoad(key)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+
+		if t.After(updateTime) {
+			value, updateTime = v, t
+		}
+	}
+
+	if updateTime.IsZero() {
+		return 0, time.Time{}, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+
+	return value, updateTime, nil
+}
+
+// parseTimestamp parses the timestamp of annotation value, which is either Unix seconds or
+// RFC3339. If legacyLocation is not nil, the timestamp in utils.TimeFormat is the legacy
+// local time in legacyLocation, whose literal "Z" does not mean UTC.
+func parseTimestamp(updatetimeStr string, legacyLocation *time.Location) (time.Time, error) {
+	if len(updatetimeStr) < MinTimestampStrLength {
+		return time.Time{}, fmt.Errorf("illegel timestamp: %s", updatetimeStr)
+	}
+
+	if seconds, err := strconv.ParseInt(updatetimeStr, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+
+	if legacyLocation != nil {
+		if updateTime, err := time.ParseInLocation(utils.TimeFormat, updatetimeStr, legacyLocation); err == nil {
+			return updateTime, nil
+		}
+	}
+
+	updateTime, err := time.Parse(time.RFC3339, updatetimeStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse timestamp: %v", err)
+	}
+
+	return updateTime, nil
+}
+
+// skewTolerantLoadSource tolerates the clock skew between scheduler and node annotator.
+type skewTolerantLoadSource struct {
+	loadSource
+	tolerance time.Duration
+}
+
+func (s skewTolerantLoadSource) getLoad(key string) (float64, time.Time, error) {
+	value, updateTime, err := s.loadSource.getLoad(key)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	now := time.Now()
+	if updateTime.After(now.Add(s.tolerance)) {
+		return 0, time.Time{}, fmt.Errorf("timestamp[%s] of %s is in the future", updateTime.Format(time.RFC3339), key)
+	}
+
+	// data is considered up to tolerance newer, but never newer than now.
+	updateTime = updateTime.Add(s.tolerance)
+	if updateTime.After(now) {
+		updateTime = now
+	}
+
+	return value, updateTime, nil
+}
+
+// newLoadSource returns the load source of node annotations, which reads the JSON encoded
+// annotation as well as the legacy ones. The timestamps of legacy annotations are read in
+// the legacy format if legacyTimestamps is true.
+func newLoadSource(nodeName string, anno map[string]string, legacyTimestamps bool) loadSource {
+	var legacy loadSource = annotationLoadSource(anno)
+	if legacyTimestamps {
+		legacy = legacyTimestampLoadSource(anno)
+	}
+
+	structured, ok, err := newJSONAnnotationLoadSource(anno)
+	if err != nil {
+		klog.Warningf("[crane] failed to parse load annotation of node[%s]: %v", nodeName, err)
+	}
+	if !ok {
+		return legacy
+	}
+
+	return multiLoadSource{structured, legacy}
+}
@@ -0,0 +1,154 @@
+package dynamic
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/nodeload/v1alpha1"
+	"github.com/gocrane/crane-scheduler/pkg/utils"
+)
+
+func TestMultiLoadSource(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	nodeLoad := &v1alpha1.NodeLoad{
+		Metrics: map[string]v1alpha1.MetricValue{
+			"cpu_usage_avg_5m": {Value: 0.3, Timestamp: metav1.NewTime(now)},
+			"mem_usage_avg_5m": {Value: 0.4, Timestamp: metav1.NewTime(now.Add(-time.Hour))},
+		},
+	}
+	annotations := legacyTimestampLoadSource{
+		"cpu_usage_avg_5m":     "0.50000," + now.Add(-time.Minute).In(utils.GetLocation()).Format(utils.TimeFormat),
+		"mem_usage_avg_5m":     "0.60000," + now.Add(-time.Minute).In(utils.GetLocation()).Format(utils.TimeFormat),
+		"cpu_usage_max_avg_1h": "0.70000," + now.In(utils.GetLocation()).Format(utils.TimeFormat),
+	}
+
+	source := multiLoadSource{newNodeLoadSource(nodeLoad), annotations}
+
+	tests := []struct {
+		key     string
+		want    float64
+		wantErr bool
+	}{
+		{key: "cpu_usage_avg_5m", want: 0.3},
+		{key: "mem_usage_avg_5m", want: 0.6},
+		{key: "cpu_usage_max_avg_1h", want: 0.7},
+		{key: "mem_usage_max_avg_1h", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, _, err := source.getLoad(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getLoad() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("getLoad() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewLoadSource(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	legacyTime := now.Add(-time.Minute).In(utils.GetLocation()).Format(utils.TimeFormat)
+
+	anno := map[string]string{
+		v1alpha1.LoadAnnotationKey: `{"metrics":{"cpu_usage_avg_5m":{"value":0.3,"timestamp":"` + now.UTC().Format(time.RFC3339) + `"}},` +
+			`"hotValue":{"value":2,"timestamp":"` + now.UTC().Format(time.RFC3339) + `"}}`,
+		"cpu_usage_avg_5m": "0.50000," + legacyTime,
+		"mem_usage_avg_5m": "0.60000," + legacyTime,
+	}
+
+	source := newLoadSource("node-1", anno, true)
+
+	for key, want := range map[string]float64{"cpu_usage_avg_5m": 0.3, "mem_usage_avg_5m": 0.6, NodeHotValue: 2} {
+		got, _, err := source.getLoad(key)
+		if err != nil {
+			t.Fatalf("getLoad(%s) error = %v", key, err)
+		}
+		if got != want {
+			t.Errorf("getLoad(%s) = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	want := time.Date(2022, 3, 1, 8, 0, 0, 0, time.UTC)
+	legacyLocation := time.FixedZone("UTC+8", 8*3600)
+
+	tests := []struct {
+		name             string
+		timestamp        string
+		legacyTimestamps bool
+	}{
+		{name: "rfc3339 in utc", timestamp: "2022-03-01T08:00:00+00:00"},
+		{name: "rfc3339 in utc with Z", timestamp: "2022-03-01T08:00:00Z"},
+		{name: "rfc3339 with offset", timestamp: "2022-03-01T16:00:00+08:00"},
+		{name: "unix seconds", timestamp: "1646121600"},
+		{name: "legacy local time", timestamp: "2022-03-01T16:00:00Z", legacyTimestamps: true},
+		{name: "rfc3339 with offset and legacy timestamps", timestamp: "2022-03-01T16:00:00+08:00", legacyTimestamps: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var location *time.Location
+			if tt.legacyTimestamps {
+				location = legacyLocation
+			}
+
+			got, err := parseTimestamp(tt.timestamp, location)
+			if err != nil {
+				t.Fatalf("parseTimestamp() error = %v", err)
+			}
+			if !got.Equal(want) {
+				t.Errorf("parseTimestamp() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSkewTolerantLoadSource(t *testing.T) {
+	now := time.Now()
+
+	anno := annotationLoadSource{
+		"cpu_usage_avg_5m":     "0.1," + utils.FormatTimestamp(now.Add(30*time.Second)),
+		"mem_usage_avg_5m":     "0.2," + utils.FormatTimestamp(now.Add(5*time.Minute)),
+		"cpu_usage_max_avg_1h": "0.3," + utils.FormatTimestamp(now.Add(-5*time.Minute)),
+	}
+	source := skewTolerantLoadSource{loadSource: anno, tolerance: time.Minute}
+
+	if _, updateTime, err := source.getLoad("cpu_usage_avg_5m"); err != nil || updateTime.After(time.Now()) {
+		t.Errorf("getLoad() of timestamp within tolerance = %v, %v, want no later than now", updateTime, err)
+	}
+
+	if _, _, err := source.getLoad("mem_usage_avg_5m"); err == nil {
+		t.Errorf("getLoad() of timestamp beyond tolerance should fail")
+	}
+
+	if _, updateTime, err := source.getLoad("cpu_usage_max_avg_1h"); err != nil || updateTime.Sub(now) < -4*time.Minute-time.Second {
+		t.Errorf("getLoad() of past timestamp = %v, %v, want shifted by tolerance", updateTime, err)
+	}
+}
+
+func TestGetNodeLoadSourceClockSkewTolerance(t *testing.T) {
+	future := time.Now().Add(5 * time.Minute)
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:        "node",
+		Annotations: map[string]string{"cpu_usage_avg_5m": "0.1," + utils.FormatTimestamp(future)},
+	}}
+
+	// the timestamps are taken as they are without tolerance, as before it was introduced.
+	ds := &DynamicScheduler{}
+	if _, updateTime, err := ds.getNodeLoadSource(node).getLoad("cpu_usage_avg_5m"); err != nil || updateTime.Unix() != future.Unix() {
+		t.Errorf("getLoad() without tolerance = %v, %v, want %v", updateTime, err, future)
+	}
+
+	ds = &DynamicScheduler{clockSkewTolerance: time.Minute}
+	if _, _, err := ds.getNodeLoadSource(node).getLoad("cpu_usage_avg_5m"); err == nil {
+		t.Errorf("getLoad() of timestamp beyond tolerance should fail")
+	}
+}
@@ -0,0 +1,164 @@
+package dynamic
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+// parsedLoad is the load data of a metric parsed in advance.
+type parsedLoad struct {
+	value      float64
+	updateTime time.Time
+	err        error
+}
+
+// parsedLoadSource is the load data parsed from node annotations in advance, so that Filter
+// and Score only do lookups.
+type parsedLoadSource map[string]parsedLoad
+
+func (s parsedLoadSource) getLoad(key string) (float64, time.Time, error) {
+	load, ok := s[key]
+	if !ok {
+		return 0, time.Time{}, fmt.Errorf("key[%s] not found", key)
+	}
+
+	return load.value, load.updateTime, load.err
+}
+
+// parseLoadSource parses the load data of keys in node annotations, either legacy or JSON
+// encoded. Other annotations of node are never parsed.
+func parseLoadSource(node *v1.Node, keys []string, legacyTimestamps bool) parsedLoadSource {
+	source := newLoadSource(node.Name, node.Annotations, legacyTimestamps)
+
+	parsed := make(parsedLoadSource, len(keys))
+	for _, key := range keys {
+		value, updateTime, err := source.getLoad(key)
+		parsed[key] = parsedLoad{value: value, updateTime: updateTime, err: err}
+	}
+
+	return parsed
+}
+
+// getLoadKeys returns the keys of load data read by policy, which are the metrics synced for
+// any node and the hot value.
+func getLoadKeys(p *policy.DynamicSchedulerPolicy) []string {
+	keys := sets.NewString(NodeHotValue)
+	for _, syncPolicy := range p.Spec.SyncPeriod {
+		keys.Insert(syncPolicy.Name)
+	}
+	for _, pool := range p.Spec.NodePools {
+		for _, syncPolicy := range pool.SyncPeriod {
+			keys.Insert(syncPolicy.Name)
+		}
+	}
+
+	return keys.List()
+}
+
+type loadCacheEntry struct {
+	resourceVersion string
+	// generation is the generation of keys the entry is parsed with.
+	generation int64
+	source     parsedLoadSource
+}
+
+// loadCache caches the load data parsed from node annotations, keyed by node name and
+// resourceVersion, which is populated by node informer events.
+type loadCache struct {
+	lock    sync.RWMutex
+	entries map[string]*loadCacheEntry
+	// keys are the keys of load data parsed, which are bumped to a new generation once the
+	// policy has been changed.
+	keys       []string
+	generation int64
+	// legacyTimestamps reads the timestamps of annotations in the legacy format.
+	legacyTimestamps bool
+}
+
+func newLoadCache(legacyTimestamps bool) *loadCache {
+	return &loadCache{
+		entries:          map[string]*loadCacheEntry{},
+		legacyTimestamps: legacyTimestamps,
+	}
+}
+
+// setKeys replaces the keys of load data parsed, the entries parsed with the former keys
+// are parsed again once read.
+func (c *loadCache) setKeys(keys []string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.keys = keys
+	c.generation++
+}
+
+// get returns the parsed load data of node, which is parsed and cached if the cache entry
+// is missing or stale.
+func (c *loadCache) get(node *v1.Node) parsedLoadSource {
+	c.lock.RLock()
+	entry, ok := c.entries[node.Name]
+	generation := c.generation
+	c.lock.RUnlock()
+
+	if ok && entry.resourceVersion == node.ResourceVersion && entry.generation == generation {
+		return entry.source
+	}
+
+	return c.update(node)
+}
+
+func (c *loadCache) update(node *v1.Node) parsedLoadSource {
+	c.lock.RLock()
+	keys, generation := c.keys, c.generation
+	c.lock.RUnlock()
+
+	source := parseLoadSource(node, keys, c.legacyTimestamps)
+
+	c.lock.Lock()
+	// the entry parsed with stale keys is never cached.
+	if generation == c.generation {
+		c.entries[node.Name] = &loadCacheEntry{resourceVersion: node.ResourceVersion, generation: generation, source: source}
+	}
+	c.lock.Unlock()
+
+	return source
+}
+
+func (c *loadCache) delete(nodeName string) {
+	c.lock.Lock()
+	delete(c.entries, nodeName)
+	c.lock.Unlock()
+}
+
+// eventHandler keeps the cache up to date with node events.
+func (c *loadCache) eventHandler() cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if node, ok := obj.(*v1.Node); ok {
+				c.update(node)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if node, ok := newObj.(*v1.Node); ok {
+				c.update(node)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			switch t := obj.(type) {
+			case *v1.Node:
+				c.delete(t.Name)
+			case cache.DeletedFinalStateUnknown:
+				if node, ok := t.Obj.(*v1.Node); ok {
+					c.delete(node.Name)
+				}
+			}
+		},
+	}
+}
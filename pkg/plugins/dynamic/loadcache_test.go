@@ -0,0 +1,123 @@
+package dynamic
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/loader"
+	"github.com/gocrane/crane-scheduler/pkg/utils"
+)
+
+func newAnnotatedNode(name, resourceVersion string, usage float64) *v1.Node {
+	timestamp := utils.FormatTimestamp(time.Now())
+
+	anno := map[string]string{NodeHotValue: "0," + timestamp}
+	for _, metric := range []string{"cpu_usage_avg_5m", "cpu_usage_max_avg_1h", "cpu_usage_max_avg_1d",
+		"mem_usage_avg_5m", "mem_usage_max_avg_1h", "mem_usage_max_avg_1d"} {
+		anno[metric] = fmt.Sprintf("%.5f,%s", usage, timestamp)
+	}
+
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			ResourceVersion: resourceVersion,
+			Annotations:     anno,
+		},
+	}
+}
+
+func TestLoadCache(t *testing.T) {
+	c := newLoadCache(false)
+	c.setKeys(getLoadKeys(&policy.DynamicSchedulerPolicy{Spec: policy.PolicySpec{
+		SyncPeriod: []policy.SyncPolicy{{Name: "cpu_usage_avg_5m"}},
+	}}))
+	handler := c.eventHandler()
+
+	node := newAnnotatedNode("node1", "1", 0.3)
+	node.Annotations["kubeadm.alpha.kubernetes.io/cri-socket"] = "/run/containerd/containerd.sock"
+	handler.OnAdd(node)
+
+	if value, _, err := c.get(node).getLoad("cpu_usage_avg_5m"); err != nil || value != 0.3 {
+		t.Errorf("expected 0.3, got %v, %v", value, err)
+	}
+	// only the metrics synced by policy and the hot value are parsed.
+	if got := len(c.get(node)); got != 2 {
+		t.Errorf("expected 2 keys parsed, got %d: %v", got, c.get(node))
+	}
+
+	// the keys of a new policy are parsed once read.
+	c.setKeys(getLoadKeys(&policy.DynamicSchedulerPolicy{Spec: policy.PolicySpec{
+		SyncPeriod: []policy.SyncPolicy{{Name: "cpu_usage_avg_5m"}},
+		NodePools: []policy.NodePoolPolicy{
+			{Name: "pool", SyncPeriod: []policy.SyncPolicy{{Name: "mem_usage_avg_5m"}}},
+		},
+	}}))
+	if value, _, err := c.get(node).getLoad("mem_usage_avg_5m"); err != nil || value != 0.3 {
+		t.Errorf("expected 0.3 of mem_usage_avg_5m, got %v, %v", value, err)
+	}
+
+	// a stale entry is refreshed even if the update event has not been received.
+	updated := newAnnotatedNode("node1", "2", 0.5)
+	if value, _, err := c.get(updated).getLoad("cpu_usage_avg_5m"); err != nil || value != 0.5 {
+		t.Errorf("expected 0.5, got %v, %v", value, err)
+	}
+
+	handler.OnDelete(cache.DeletedFinalStateUnknown{Key: "node1", Obj: updated})
+	if _, ok := c.entries["node1"]; ok {
+		t.Errorf("expected entry of node1 to be deleted")
+	}
+}
+
+// BenchmarkSchedulePod measures the latency of filtering and scoring 5000 nodes for a pod.
+func BenchmarkSchedulePod(b *testing.B) {
+	schedulerPolicy, err := loader.LoadPolicyFromFile(filepath.Join("..", "..", "..", "deploy", "manifests", "dynamic", "policy.yaml"))
+	if err != nil {
+		b.Fatalf("failed to load policy: %v", err)
+	}
+
+	nodeInfos := make([]*framework.NodeInfo, 5000)
+	for i := range nodeInfos {
+		nodeInfos[i] = framework.NewNodeInfo()
+		nodeInfos[i].SetNode(newAnnotatedNode(fmt.Sprintf("node%d", i), "1", float64(i%60)/100))
+	}
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod"}}
+
+	for _, tt := range []struct {
+		name      string
+		loadCache *loadCache
+	}{
+		{name: "parsing"},
+		{name: "cached", loadCache: newLoadCache(false)},
+	} {
+		b.Run(tt.name, func(b *testing.B) {
+			ds := &DynamicScheduler{loadCache: tt.loadCache}
+			ds.updatePolicy(schedulerPolicy)
+
+			if tt.loadCache != nil {
+				for _, nodeInfo := range nodeInfos {
+					tt.loadCache.update(nodeInfo.Node())
+				}
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				state := framework.NewCycleState()
+				for _, nodeInfo := range nodeInfos {
+					if status := ds.Filter(context.TODO(), state, pod, nodeInfo); status.IsSuccess() {
+						ds.scoreNode(state, pod, nodeInfo.Node())
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package dynamic
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/klog/v2"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/helper"
+)
+
+const (
+	// DynamicSubsystem is the subsystem name of Dynamic plugin metrics.
+	DynamicSubsystem = "crane_scheduler_dynamic"
+
+	// DefaultLoadDataStateRecordPeriod is the interval of counting nodes by the state of load data.
+	DefaultLoadDataStateRecordPeriod = 30 * time.Second
+
+	extensionPointFilter = "Filter"
+	extensionPointScore  = "Score"
+)
+
+var (
+	loadDataNodes = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      DynamicSubsystem,
+			Name:           "load_data_nodes",
+			Help:           "Number of nodes whose load data of each metric is fresh, expired or missing.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"metric", "state"})
+
+	missingDataNodes = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      DynamicSubsystem,
+			Name:           "missing_data_nodes",
+			Help:           "Number of nodes whose load data of each metric is expired or missing, by the extension point and how they are treated according to missingData.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"extension_point", "metric", "treatment"})
+
+	shadowFilterRejections = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DynamicSubsystem,
+			Name:           "shadow_filter_rejections_total",
+			Help:           "Number of times nodes would have been rejected by Filter in shadow mode, by metric and node.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"metric", "node"})
+
+	dynamicMetrics = []metrics.Registerable{
+		loadDataNodes,
+		missingDataNodes,
+		shadowFilterRejections,
+	}
+
+	registerMetrics sync.Once
+)
+
+// RegisterMetrics registers Dynamic plugin metrics.
+func RegisterMetrics() {
+	registerMetrics.Do(func() {
+		for _, metric := range dynamicMetrics {
+			legacyregistry.MustRegister(metric)
+		}
+	})
+}
+
+// missingDataKey identifies the nodes treated the same way for missing load data of metric.
+type missingDataKey struct {
+	extensionPoint string
+	metric         string
+}
+
+// recordLoadDataStates counts nodes by the state of their load data of each metric in sync policy,
+// and counts the nodes without fresh load data by how predicates and priorities treat them, so
+// that missing data, such as when Prometheus is down, does not change placement silently. Profiles
+// are not taken into account, as they are selected by pods.
+func (ds *DynamicScheduler) recordLoadDataStates(nodeLister corelisters.NodeLister) {
+	nodes, err := nodeLister.List(labels.Everything())
+	if err != nil {
+		klog.Warningf("[crane] failed to list nodes: %v", err)
+		return
+	}
+
+	spec := ds.getPolicy().Spec
+	nodePools := helper.NewNodePools(spec.NodePools)
+
+	counts := map[string]map[loadDataState]int{}
+	treatments := map[missingDataKey]map[policy.MissingDataPolicy]int{}
+	countTreatment := func(extensionPoint, metric string, missingData, defaultPolicy policy.MissingDataPolicy, state loadDataState) {
+		key := missingDataKey{extensionPoint: extensionPoint, metric: metric}
+		if _, ok := treatments[key]; !ok {
+			treatments[key] = map[policy.MissingDataPolicy]int{}
+		}
+		if state != loadDataFresh {
+			treatments[key][resolveMissingData(missingData, defaultPolicy, state)]++
+		}
+	}
+
+	for _, node := range nodes {
+		source := ds.getNodeLoadSource(node)
+		nodeSpec := helper.ApplyNodePool(spec, nodePools.Match(node))
+		syncPeriod := nodeSpec.SyncPeriod
+
+		states := map[string]loadDataState{}
+		for _, sp := range syncPeriod {
+			activeDuration, err := getActiveDuration(syncPeriod, sp.Name)
+			if err != nil {
+				continue
+			}
+
+			if _, ok := counts[sp.Name]; !ok {
+				counts[sp.Name] = map[loadDataState]int{}
+			}
+
+			_, state, _ := readResourceUsage(source, sp.Name, activeDuration)
+			counts[sp.Name][state]++
+			states[sp.Name] = state
+		}
+
+		for _, predicate := range nodeSpec.Predicate {
+			if state, ok := states[predicate.Name]; ok {
+				countTreatment(extensionPointFilter, predicate.Name, predicate.MissingData, defaultPredicateMissingData, state)
+			}
+		}
+		for _, priority := range nodeSpec.Priority {
+			if state, ok := states[priority.Name]; ok {
+				countTreatment(extensionPointScore, priority.Name, priority.MissingData, defaultPriorityMissingData, state)
+			}
+		}
+	}
+
+	// metrics removed from the policy are not reported any more.
+	loadDataNodes.Reset()
+	for metric, states := range counts {
+		for _, state := range []loadDataState{loadDataFresh, loadDataExpired, loadDataMissing} {
+			loadDataNodes.WithLabelValues(metric, string(state)).Set(float64(states[state]))
+		}
+	}
+
+	missingDataNodes.Reset()
+	for key, counts := range treatments {
+		for _, treatment := range []policy.MissingDataPolicy{policy.MissingDataIgnoreNode, policy.MissingDataTreatAsOverloaded,
+			policy.MissingDataTreatAsIdle, policy.MissingDataUseLastKnown} {
+			missingDataNodes.WithLabelValues(key.extensionPoint, key.metric, string(treatment)).Set(float64(counts[treatment]))
+		}
+	}
+}
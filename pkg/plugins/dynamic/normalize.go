@@ -0,0 +1,82 @@
+package dynamic
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/config"
+)
+
+// normalizeFunc spreads the scores of candidate nodes over the range of node score.
+type normalizeFunc func(scores framework.NodeScoreList)
+
+// getNormalizeFunc returns the normalizeFunc of strategy, which is nil for None.
+func getNormalizeFunc(strategy config.ScoreNormalizationStrategy) (normalizeFunc, error) {
+	switch strategy {
+	case "", config.ScoreNormalizationNone:
+		return nil, nil
+	case config.ScoreNormalizationMinMax:
+		return minMaxNormalize, nil
+	case config.ScoreNormalizationRank:
+		return rankNormalize, nil
+	default:
+		return nil, fmt.Errorf("unknown score normalization strategy %q", strategy)
+	}
+}
+
+// minMaxNormalize scales the scores linearly, so that the lowest one becomes MinNodeScore and
+// the highest one becomes MaxNodeScore. Scores are kept if all of them are equal.
+func minMaxNormalize(scores framework.NodeScoreList) {
+	if len(scores) == 0 {
+		return
+	}
+
+	min, max := scores[0].Score, scores[0].Score
+	for _, s := range scores {
+		if s.Score < min {
+			min = s.Score
+		}
+		if s.Score > max {
+			max = s.Score
+		}
+	}
+
+	if max == min {
+		return
+	}
+
+	for i := range scores {
+		scores[i].Score = framework.MinNodeScore + (scores[i].Score-min)*(framework.MaxNodeScore-framework.MinNodeScore)/(max-min)
+	}
+}
+
+// rankNormalize scores nodes by the rank of their scores, nodes with the same score share
+// the same rank. Scores are kept if all of them are equal.
+func rankNormalize(scores framework.NodeScoreList) {
+	distinct := map[int64]struct{}{}
+	for _, s := range scores {
+		distinct[s.Score] = struct{}{}
+	}
+
+	if len(distinct) <= 1 {
+		return
+	}
+
+	sorted := make([]int64, 0, len(distinct))
+	for score := range distinct {
+		sorted = append(sorted, score)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	ranks := make(map[int64]int64, len(sorted))
+	for i, score := range sorted {
+		ranks[score] = int64(i)
+	}
+
+	maxRank := int64(len(sorted) - 1)
+	for i := range scores {
+		scores[i].Score = framework.MinNodeScore + ranks[scores[i].Score]*(framework.MaxNodeScore-framework.MinNodeScore)/maxRank
+	}
+}
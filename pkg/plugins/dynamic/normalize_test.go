@@ -0,0 +1,68 @@
+package dynamic
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/config"
+)
+
+func TestNormalizeScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy config.ScoreNormalizationStrategy
+		scores   []int64
+		want     []int64
+	}{
+		{
+			name:     "min max",
+			strategy: config.ScoreNormalizationMinMax,
+			scores:   []int64{40, 45, 60},
+			want:     []int64{0, 25, 100},
+		},
+		{
+			name:     "min max with equal scores",
+			strategy: config.ScoreNormalizationMinMax,
+			scores:   []int64{50, 50},
+			want:     []int64{50, 50},
+		},
+		{
+			name:     "rank",
+			strategy: config.ScoreNormalizationRank,
+			scores:   []int64{40, 58, 45, 45, 60},
+			want:     []int64{0, 66, 33, 33, 100},
+		},
+		{
+			name:     "rank with single node",
+			strategy: config.ScoreNormalizationRank,
+			scores:   []int64{42},
+			want:     []int64{42},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalize, err := getNormalizeFunc(tt.strategy)
+			if err != nil {
+				t.Fatalf("getNormalizeFunc() error = %v", err)
+			}
+
+			scores := make(framework.NodeScoreList, len(tt.scores))
+			for i, s := range tt.scores {
+				scores[i] = framework.NodeScore{Score: s}
+			}
+
+			normalize(scores)
+
+			got := make([]int64, len(scores))
+			for i, s := range scores {
+				got[i] = s.Score
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("normalize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,19 +3,35 @@ package dynamic
 import (
 	"context"
 	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 
+	craneclientset "github.com/gocrane/crane-scheduler/pkg/generated/clientset/versioned"
+	craneinformers "github.com/gocrane/crane-scheduler/pkg/generated/informers/externalversions"
+	nodeloadlisters "github.com/gocrane/crane-scheduler/pkg/generated/listers/nodeload/v1alpha1"
 	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/config"
 	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/loader"
 	"github.com/gocrane/crane-scheduler/pkg/utils"
 )
 
 var _ framework.FilterPlugin = &DynamicScheduler{}
 var _ framework.ScorePlugin = &DynamicScheduler{}
+var _ framework.ScoreExtensions = &DynamicScheduler{}
+var _ framework.PostFilterPlugin = &DynamicScheduler{}
+var _ framework.ReservePlugin = &DynamicScheduler{}
+var _ io.Closer = &DynamicScheduler{}
 
 const (
 	// Name is the name of the plugin used in the plugin registry and configurations.
@@ -24,8 +40,52 @@ const (
 
 // Dynamic-scheduler is a real load-aware scheduler plugin.
 type DynamicScheduler struct {
-	handle          framework.Handle
-	schedulerPolicy *policy.DynamicSchedulerPolicy
+	handle framework.Handle
+	// nodeLoadLister is nil unless reading node load from NodeLoad objects is enabled.
+	nodeLoadLister nodeloadlisters.NodeLoadLister
+	// clockSkewTolerance is the tolerated clock skew between scheduler and node annotator.
+	clockSkewTolerance time.Duration
+	// legacyTimestamps reads the timestamps of annotations in the legacy local time format.
+	legacyTimestamps bool
+	// normalize is nil if scores need no normalization.
+	normalize normalizeFunc
+	// estimatePodUsage enables filtering and scoring by the projected usage after placement.
+	estimatePodUsage bool
+	// replicaSetLister and statefulSetLister are used to find the historical usage of workload,
+	// which are nil unless pod usage estimation is enabled.
+	replicaSetLister  appslisters.ReplicaSetLister
+	statefulSetLister appslisters.StatefulSetLister
+	// loadCache caches the load data parsed from node annotations.
+	loadCache *loadCache
+	// shadowMode makes the plugin dry-run, which never rejects nodes and scores all nodes 0.
+	shadowMode bool
+	// shadowModeEvents records the nodes which would have been rejected as pod events.
+	shadowModeEvents bool
+	// shadowStateLock guards the creation of shadow rejections state by parallel Filter calls.
+	shadowStateLock sync.Mutex
+	// relaxationDelay is how long a pod stays pending before its load thresholds are relaxed,
+	// and 0 disables the relaxation.
+	relaxationDelay time.Duration
+	// hotValueWeight is the factor multiplied to the hot value of node.
+	hotValueWeight float64
+	// inFlight records the pods assigned by this scheduler, which are not counted by hot value yet.
+	inFlight *inFlightTracker
+	// relaxations records the relaxed load thresholds of pending pods.
+	relaxations *relaxationTracker
+	// namespaceLister is used to find the policy profile selected by namespace label.
+	namespaceLister corelisters.NamespaceLister
+	// schedulerPolicy stores *policy.DynamicSchedulerPolicy, which is swapped atomically
+	// once the policy file changes.
+	schedulerPolicy atomic.Value
+	// policyLock serializes the updates of policy along with the keys of load cache.
+	policyLock sync.Mutex
+	// profileSpecs caches the policies resolved for each profile of the current policy.
+	profileSpecs profileSpecCache
+	// cancelPolicy cancels the subscription to the policy shared by all profiles.
+	cancelPolicy func()
+	// stopCh stops the goroutines of the plugin once closed.
+	stopCh    chan struct{}
+	closeOnce sync.Once
 }
 
 // Name returns name of the plugin.
@@ -37,8 +97,8 @@ func (ds *DynamicScheduler) Name() string {
 // checkes if the real load of one node is too high.
 // It returns a list of failure reasons if the node is overload.
 func (ds *DynamicScheduler) Filter(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
-	// ignore daemonset pod
-	if utils.IsDaemonsetPod(pod) {
+	// ignore daemonset pod and pods exempted by policy
+	if utils.IsDaemonsetPod(pod) || ds.getPolicySpecState(state, pod).exempted {
 		return framework.NewStatus(framework.Success, "")
 	}
 
@@ -47,29 +107,46 @@ func (ds *DynamicScheduler) Filter(ctx context.Context, state *framework.CycleSt
 		return framework.NewStatus(framework.Error, "node not found")
 	}
 
-	nodeAnnotations, nodeName := nodeInfo.Node().Annotations, nodeInfo.Node().Name
-	if nodeAnnotations == nil {
-		nodeAnnotations = map[string]string{}
+	source, nodeName := ds.getLoadSource(state, pod, node), node.Name
+
+	spec := ds.getPolicySpec(state, pod, node)
+	// metrics the node is overloaded in, which are only collected in shadow mode.
+	var overloaded []string
+
+	// thresholds may have been relaxed for the pod pending long.
+	var relaxationLevel int
+	if ds.relaxations != nil {
+		relaxationLevel = ds.relaxations.get(pod.UID)
 	}
 
-	for _, policy := range ds.schedulerPolicy.Spec.Predicate {
-		activeDuration, err := getActiveDuration(ds.schedulerPolicy.Spec.SyncPeriod, policy.Name)
+	for _, policy := range spec.Predicate {
+		policy = relaxPredicate(policy, relaxationLevel)
+
+		activeDuration, err := getActiveDuration(spec.SyncPeriod, policy.Name)
 
 		if err != nil || activeDuration == 0 {
 			klog.Warningf("[crane] failed to get active duration: %v", err)
 			continue
 		}
 
-		if isOverLoad(nodeName, nodeAnnotations, policy, activeDuration) {
-			return framework.NewStatus(framework.Unschedulable, fmt.Sprintf("Load[%s] of node[%s] is too high", policy.Name, nodeName))
+		if isOverLoad(nodeName, source, policy, activeDuration) {
+			if !ds.shadowMode {
+				return framework.NewStatus(framework.Unschedulable, fmt.Sprintf("Load[%s] of node[%s] is too high", policy.Name, nodeName))
+			}
+			overloaded = append(overloaded, policy.Name)
 		}
 
 	}
+
+	if len(overloaded) > 0 {
+		ds.recordShadowRejection(state, pod, nodeName, overloaded)
+	}
+
 	return framework.NewStatus(framework.Success, "")
 }
 
 // Score invoked at the Score extension point.
-// It gets metric data from node annotation, and favors nodes with the least real resource usage.
+// It gets metric data from NodeLoad object or node annotation, and favors nodes with the least real resource usage.
 func (ds *DynamicScheduler) Score(ctx context.Context, state *framework.CycleState, p *v1.Pod, nodeName string) (int64, *framework.Status) {
 	nodeInfo, err := ds.handle.SnapshotSharedLister().NodeInfos().Get(nodeName)
 	if err != nil {
@@ -81,40 +158,245 @@ func (ds *DynamicScheduler) Score(ctx context.Context, state *framework.CycleSta
 		return 0, framework.NewStatus(framework.Error, "node not found")
 	}
 
-	nodeAnnotations := node.Annotations
-	if nodeAnnotations == nil {
-		nodeAnnotations = map[string]string{}
+	score := ds.scoreNode(state, p, node)
+	if ds.shadowMode {
+		return 0, nil
 	}
 
-	score, hotValue := getNodeScore(node.Name, nodeAnnotations, ds.schedulerPolicy.Spec), getNodeHotValue(node)
+	return score, nil
+}
+
+// scoreNode scores node by its real load and hot value.
+func (ds *DynamicScheduler) scoreNode(state *framework.CycleState, p *v1.Pod, node *v1.Node) int64 {
+	source := ds.getLoadSource(state, p, node)
+
+	spec := ds.getPolicySpec(state, p, node)
+
+	score := getNodeScore(node.Name, source, spec)
+	hotValue, hotValueTime := getNodeHotValue(node.Name, source)
 
-	score = score - int(hotValue*10)
+	// pods assigned by this scheduler recently are not counted by the hot value yet.
+	inFlightHotValue := ds.getInFlightHotValue(node.Name, spec.HotValue, hotValueTime)
 
-	finalScore := utils.NormalizeScore(int64(score),framework.MaxNodeScore,framework.MinNodeScore)
+	score = score - int((hotValue+inFlightHotValue)*ds.hotValueWeight)
 
-	klog.V(4).Infof("[crane] Node[%s]'s final score is %d, while score is %d and hot value is %f", node.Name, finalScore, score, hotValue)
+	finalScore := utils.NormalizeScore(int64(score), framework.MaxNodeScore, framework.MinNodeScore)
 
-	return finalScore, nil
+	klog.V(4).Infof("[crane] Node[%s]'s final score is %d, while score is %d, hot value is %f and in-flight hot value is %f", node.Name, finalScore, score, hotValue, inFlightHotValue)
+
+	return finalScore
 }
 
+// ScoreExtensions of the Score plugin.
 func (ds *DynamicScheduler) ScoreExtensions() framework.ScoreExtensions {
+	if ds.normalize == nil {
+		return nil
+	}
+	return ds
+}
+
+// NormalizeScore invoked after scoring all nodes, spreads the scores of candidate nodes
+// according to the score normalization strategy.
+func (ds *DynamicScheduler) NormalizeScore(ctx context.Context, state *framework.CycleState, p *v1.Pod, scores framework.NodeScoreList) *framework.Status {
+	ds.normalize(scores)
 	return nil
 }
 
+// getLoadSource returns where to read the load of node from. NodeLoad object is preferred
+// if enabled, while node annotations are kept as fallback. The expected usage of pod is
+// added to the load of node if pod usage estimation is enabled.
+func (ds *DynamicScheduler) getLoadSource(state *framework.CycleState, pod *v1.Pod, node *v1.Node) loadSource {
+	source := ds.getNodeLoadSource(node)
+
+	if ds.estimatePodUsage {
+		source = projectedLoadSource{
+			loadSource: source,
+			podUsage:   ds.getPodUsage(state, pod),
+			capacity:   node.Status.Capacity,
+		}
+	}
+
+	return source
+}
+
+// getNodeLoadSource returns where to read the current load of node from.
+func (ds *DynamicScheduler) getNodeLoadSource(node *v1.Node) loadSource {
+	var source loadSource
+	if ds.loadCache != nil {
+		source = ds.loadCache.get(node)
+	} else {
+		source = newLoadSource(node.Name, node.Annotations, ds.legacyTimestamps)
+	}
+
+	if ds.nodeLoadLister != nil {
+		nodeLoad, err := ds.nodeLoadLister.Get(node.Name)
+		if err == nil {
+			source = multiLoadSource{newNodeLoadSource(nodeLoad), source}
+		} else if !errors.IsNotFound(err) {
+			klog.Warningf("[crane] failed to get NodeLoad of node[%s]: %v", node.Name, err)
+		}
+	}
+
+	// the timestamps are taken as they are if clock skew is not tolerated.
+	if ds.clockSkewTolerance <= 0 {
+		return source
+	}
+
+	return skewTolerantLoadSource{loadSource: source, tolerance: ds.clockSkewTolerance}
+}
+
+func (ds *DynamicScheduler) getPolicy() *policy.DynamicSchedulerPolicy {
+	return ds.schedulerPolicy.Load().(*policy.DynamicSchedulerPolicy)
+}
+
+func (ds *DynamicScheduler) updatePolicy(p *policy.DynamicSchedulerPolicy) {
+	ds.policyLock.Lock()
+	defer ds.policyLock.Unlock()
+
+	ds.storePolicy(p)
+}
+
+// initPolicy applies p unless a policy has been applied.
+func (ds *DynamicScheduler) initPolicy(p *policy.DynamicSchedulerPolicy) {
+	ds.policyLock.Lock()
+	defer ds.policyLock.Unlock()
+
+	if ds.schedulerPolicy.Load() == nil {
+		ds.storePolicy(p)
+	}
+}
+
+func (ds *DynamicScheduler) storePolicy(p *policy.DynamicSchedulerPolicy) {
+	if ds.loadCache != nil {
+		ds.loadCache.setKeys(getLoadKeys(p))
+	}
+	ds.schedulerPolicy.Store(p)
+}
+
+// Close stops the goroutines of the plugin and cancels its subscription to the policy, which
+// is safe to call more than once.
+func (ds *DynamicScheduler) Close() error {
+	ds.closeOnce.Do(func() {
+		close(ds.stopCh)
+		if ds.cancelPolicy != nil {
+			ds.cancelPolicy()
+		}
+	})
+	return nil
+}
+
+var (
+	nodeLoadListerLock sync.Mutex
+	// nodeLoadLister is shared by the plugins of all profiles, whose informer runs for the
+	// whole process like the informers of scheduler.
+	nodeLoadLister nodeloadlisters.NodeLoadLister
+)
+
+// getNodeLoadLister returns the lister of NodeLoad objects, whose informer is started and
+// synced by the first caller.
+func getNodeLoadLister(client craneclientset.Interface) nodeloadlisters.NodeLoadLister {
+	nodeLoadListerLock.Lock()
+	defer nodeLoadListerLock.Unlock()
+
+	if nodeLoadLister == nil {
+		klog.V(4).InfoS("Start NodeLoad informer")
+		informerFactory := craneinformers.NewSharedInformerFactory(client, 0)
+		lister := informerFactory.NodeLoad().V1alpha1().NodeLoads().Lister()
+		informerFactory.Start(wait.NeverStop)
+		informerFactory.WaitForCacheSync(wait.NeverStop)
+		nodeLoadLister = lister
+	}
+
+	return nodeLoadLister
+}
+
 // NewDynamicScheduler returns a Crane Scheduler object.
-func NewDynamicScheduler(plArgs runtime.Object, h framework.Handle) (framework.Plugin, error) {
+func NewDynamicScheduler(plArgs runtime.Object, h framework.Handle) (_ framework.Plugin, err error) {
 	args, ok := plArgs.(*config.DynamicArgs)
 	if !ok {
 		return nil, fmt.Errorf("want args to be of type DynamicArgs, got %T.", plArgs)
 	}
 
-	schedulerPolicy, err := LoadPolicyFromFile(args.PolicyConfigPath)
+	normalize, err := getNormalizeFunc(args.ScoreNormalization)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get scheduler policy from config file: %v", err)
+		return nil, err
+	}
+
+	if args.ThresholdRelaxationDelay.Duration < 0 {
+		return nil, fmt.Errorf("thresholdRelaxationDelay must be greater than or equal to 0, got %v", args.ThresholdRelaxationDelay.Duration)
+	}
+
+	if args.HotValueWeight < 0 {
+		return nil, fmt.Errorf("hotValueWeight must be greater than or equal to 0, got %v", args.HotValueWeight)
+	}
+
+	ds := &DynamicScheduler{
+		handle:             h,
+		clockSkewTolerance: args.ClockSkewTolerance.Duration,
+		legacyTimestamps:   args.LegacyTimestamps,
+		normalize:          normalize,
+		estimatePodUsage:   args.EstimatePodUsage,
+		loadCache:          newLoadCache(args.LegacyTimestamps),
+		shadowMode:         args.ShadowMode,
+		shadowModeEvents:   args.ShadowModeEvents,
+		relaxationDelay:    args.ThresholdRelaxationDelay.Duration,
+		hotValueWeight:     args.HotValueWeight,
+		relaxations:        newRelaxationTracker(),
+		inFlight:           newInFlightTracker(DefaultInFlightBindingTTL),
+		stopCh:             make(chan struct{}),
+	}
+
+	// the goroutines started below are stopped if the plugin fails to be created.
+	defer func() {
+		if err != nil {
+			ds.Close()
+		}
+	}()
+
+	RegisterMetrics()
+
+	go wait.Until(ds.inFlight.gc, time.Minute, ds.stopCh)
+
+	if informerFactory := h.SharedInformerFactory(); informerFactory != nil {
+		nodeInformer := informerFactory.Core().V1().Nodes()
+		nodeInformer.Informer().AddEventHandler(ds.loadCache.eventHandler())
+		ds.namespaceLister = informerFactory.Core().V1().Namespaces().Lister()
+		informerFactory.Core().V1().Pods().Informer().AddEventHandler(ds.relaxations.eventHandler())
+		if ds.estimatePodUsage {
+			ds.replicaSetLister = informerFactory.Apps().V1().ReplicaSets().Lister()
+			ds.statefulSetLister = informerFactory.Apps().V1().StatefulSets().Lister()
+		}
+
+		go wait.Until(func() { ds.recordLoadDataStates(nodeInformer.Lister()) }, DefaultLoadDataStateRecordPeriod, ds.stopCh)
+	}
+
+	var client craneclientset.Interface
+	if args.PolicyName != "" || args.EnableNodeLoad {
+		client, err = craneclientset.NewForConfig(h.KubeConfig())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create crane clientset: %v", err)
+		}
+	}
+
+	// the policy is watched once per process, and shared by the plugins of all profiles.
+	var schedulerPolicy *policy.DynamicSchedulerPolicy
+	if args.PolicyName != "" {
+		schedulerPolicy, ds.cancelPolicy, err = loader.SubscribePolicyObject(client, args.PolicyName, ds.updatePolicy)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		schedulerPolicy, ds.cancelPolicy, err = loader.SubscribePolicyFile(args.PolicyConfigPath, ds.updatePolicy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get scheduler policy from config file: %v", err)
+		}
+	}
+	// a newer policy may have been applied by the handler.
+	ds.initPolicy(schedulerPolicy)
+
+	if args.EnableNodeLoad {
+		ds.nodeLoadLister = getNodeLoadLister(client)
 	}
 
-	return &DynamicScheduler{
-		schedulerPolicy: schedulerPolicy,
-		handle:          h,
-	}, nil
+	return ds, nil
 }
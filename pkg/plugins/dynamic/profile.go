@@ -0,0 +1,187 @@
+package dynamic
+
+import (
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/helper"
+)
+
+const (
+	// PolicyProfileKey is the key of pod annotation or namespace label selecting the policy
+	// profile, such as latency-sensitive or batch, defined in DynamicSchedulerPolicy.
+	PolicyProfileKey = "scheduler.crane.io/policy-profile"
+
+	policySpecStateKey framework.StateKey = Name + "/policy-spec"
+)
+
+// profileSpecs are the policies applied to the pods selecting a profile.
+type profileSpecs struct {
+	// spec is the policy applied on nodes not in any node pool.
+	spec policy.PolicySpec
+	// poolSpecs are the policies applied on nodes of each node pool.
+	poolSpecs map[string]policy.PolicySpec
+	// found is false if the profile is not defined, with which the default policy is applied.
+	found bool
+}
+
+// policySpecState caches the policies applied to pod in a scheduling cycle.
+type policySpecState struct {
+	*profileSpecs
+	nodePools *helper.NodePools
+	// exempted is true if the pod matches the exemption rules of policy.
+	exempted bool
+}
+
+// Clone returns the state itself, since it is never modified.
+func (s *policySpecState) Clone() framework.StateData {
+	return s
+}
+
+// selectProfile returns the name of profile selected by pod, by its annotation, the label of its
+// namespace or its PriorityClass, in the order of precedence. It returns "" if none is selected.
+func selectProfile(pod *v1.Pod, namespaceLister corelisters.NamespaceLister, profiles []policy.PolicyProfile) string {
+	if name, ok := pod.Annotations[PolicyProfileKey]; ok {
+		return name
+	}
+
+	if namespaceLister != nil {
+		ns, err := namespaceLister.Get(pod.Namespace)
+		if err != nil {
+			klog.V(4).Infof("[crane] failed to get namespace[%s]: %v", pod.Namespace, err)
+		} else if name, ok := ns.Labels[PolicyProfileKey]; ok {
+			return name
+		}
+	}
+
+	if pod.Spec.PriorityClassName != "" {
+		for _, profile := range profiles {
+			for _, priorityClassName := range profile.PriorityClassNames {
+				if priorityClassName == pod.Spec.PriorityClassName {
+					return profile.Name
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// applyProfile overrides the predicate, priority and balance policies of spec with the named profile.
+func applyProfile(spec policy.PolicySpec, name string) (policy.PolicySpec, bool) {
+	for _, profile := range spec.Profiles {
+		if profile.Name != name {
+			continue
+		}
+
+		if len(profile.Predicate) > 0 {
+			spec.Predicate = profile.Predicate
+		}
+		if len(profile.Priority) > 0 {
+			spec.Priority = profile.Priority
+		}
+		if len(profile.Balance) > 0 {
+			spec.Balance = profile.Balance
+		}
+
+		return spec, true
+	}
+
+	return spec, false
+}
+
+// getPolicySpec returns the policy applied to pod on node. Policy profile selected by pod takes
+// precedence over node pool, which takes precedence over the default policy.
+func (ds *DynamicScheduler) getPolicySpec(state *framework.CycleState, pod *v1.Pod, node *v1.Node) policy.PolicySpec {
+	s := ds.getPolicySpecState(state, pod)
+
+	if pool := s.nodePools.Match(node); pool != nil {
+		return s.poolSpecs[pool.Name]
+	}
+
+	return s.spec
+}
+
+// profileSpecCache caches the policies resolved for each profile, which are shared by pods
+// until the policy is replaced.
+type profileSpecCache struct {
+	lock sync.Mutex
+	// policy is the policy the cache is resolved from.
+	policy    *policy.DynamicSchedulerPolicy
+	nodePools *helper.NodePools
+	profiles  map[string]*profileSpecs
+}
+
+// get returns the node pools of p and the policies applied to pods selecting profile, which
+// are resolved once per policy and profile.
+func (c *profileSpecCache) get(p *policy.DynamicSchedulerPolicy, profile string) (*helper.NodePools, *profileSpecs) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.policy != p {
+		c.policy = p
+		c.nodePools = helper.NewNodePools(p.Spec.NodePools)
+		c.profiles = map[string]*profileSpecs{}
+	}
+
+	specs, ok := c.profiles[profile]
+	if !ok {
+		specs = resolveProfileSpecs(p.Spec, profile)
+		c.profiles[profile] = specs
+	}
+
+	return c.nodePools, specs
+}
+
+// resolveProfileSpecs applies profile to spec and the specs of its node pools.
+func resolveProfileSpecs(spec policy.PolicySpec, profile string) *profileSpecs {
+	specs := &profileSpecs{
+		poolSpecs: make(map[string]policy.PolicySpec, len(spec.NodePools)),
+		found:     true,
+	}
+
+	if profile == "" {
+		specs.spec = spec
+	} else {
+		specs.spec, specs.found = applyProfile(spec, profile)
+	}
+
+	for i := range spec.NodePools {
+		pool := &spec.NodePools[i]
+		specs.poolSpecs[pool.Name], _ = applyProfile(helper.ApplyNodePool(spec, pool), profile)
+	}
+
+	return specs
+}
+
+// getPolicySpecState resolves the policies applied to pod once per scheduling cycle.
+func (ds *DynamicScheduler) getPolicySpecState(state *framework.CycleState, pod *v1.Pod) *policySpecState {
+	if data, err := state.Read(policySpecStateKey); err == nil {
+		if s, ok := data.(*policySpecState); ok {
+			return s
+		}
+	}
+
+	p := ds.getPolicy()
+
+	profile := selectProfile(pod, ds.namespaceLister, p.Spec.Profiles)
+	nodePools, specs := ds.profileSpecs.get(p, profile)
+	if !specs.found {
+		klog.Warningf("[crane] policy profile[%s] of pod[%s/%s] not found, use the default one", profile, pod.Namespace, pod.Name)
+	}
+
+	s := &policySpecState{
+		profileSpecs: specs,
+		nodePools:    nodePools,
+		exempted:     helper.IsPodExempted(pod, p.Spec.Exemptions),
+	}
+
+	state.Write(policySpecStateKey, s)
+
+	return s
+}
@@ -0,0 +1,188 @@
+package dynamic
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+func TestSelectProfile(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	indexer.Add(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "online", Labels: map[string]string{PolicyProfileKey: "latency-sensitive"}}})
+	indexer.Add(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}})
+	namespaceLister := corelisters.NewNamespaceLister(indexer)
+
+	profiles := []policy.PolicyProfile{
+		{Name: "latency-sensitive"},
+		{Name: "batch", PriorityClassNames: []string{"low-priority"}},
+	}
+
+	tests := []struct {
+		name string
+		pod  *v1.Pod
+		want string
+	}{
+		{
+			name: "annotation takes precedence",
+			pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "online", Annotations: map[string]string{PolicyProfileKey: "batch"}},
+			},
+			want: "batch",
+		},
+		{
+			name: "namespace label",
+			pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "online"},
+				Spec:       v1.PodSpec{PriorityClassName: "low-priority"},
+			},
+			want: "latency-sensitive",
+		},
+		{
+			name: "priority class",
+			pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+				Spec:       v1.PodSpec{PriorityClassName: "low-priority"},
+			},
+			want: "batch",
+		},
+		{
+			name: "none",
+			pod:  &v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := selectProfile(tt.pod, namespaceLister, profiles); got != tt.want {
+				t.Errorf("selectProfile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyProfile(t *testing.T) {
+	spec := policy.PolicySpec{
+		Predicate: []policy.PredicatePolicy{{Name: "cpu_usage_avg_5m", MaxLimitPecent: 0.65}},
+		Priority:  []policy.PriorityPolicy{{Name: "cpu_usage_avg_5m", Weight: 0.2}},
+		Balance:   []policy.BalancePolicy{{Name: "cpu-mem", Metrics: []string{"cpu_usage_avg_5m", "mem_usage_avg_5m"}, Weight: 1}},
+		Profiles: []policy.PolicyProfile{
+			{Name: "batch", Predicate: []policy.PredicatePolicy{{Name: "cpu_usage_avg_5m", MaxLimitPecent: 0.9}}},
+			{Name: "balanced", Balance: []policy.BalancePolicy{{Name: "cpu-mem", Metrics: []string{"cpu_usage_avg_5m", "mem_usage_avg_5m"}, Weight: 2}}},
+		},
+	}
+
+	got, ok := applyProfile(spec, "batch")
+	if !ok || got.Predicate[0].MaxLimitPecent != 0.9 || got.Priority[0].Weight != 0.2 || got.Balance[0].Weight != 1 {
+		t.Errorf("applyProfile() = %v, %v, want predicate overridden, priority and balance inherited", got, ok)
+	}
+
+	got, ok = applyProfile(spec, "balanced")
+	if !ok || got.Predicate[0].MaxLimitPecent != 0.65 || got.Balance[0].Weight != 2 {
+		t.Errorf("applyProfile() = %v, %v, want balance overridden and predicate inherited", got, ok)
+	}
+
+	if _, ok := applyProfile(spec, "unknown"); ok {
+		t.Errorf("applyProfile() of unknown profile should fail")
+	}
+}
+
+func TestGetPolicySpec(t *testing.T) {
+	ds := &DynamicScheduler{}
+	ds.updatePolicy(&policy.DynamicSchedulerPolicy{
+		Spec: policy.PolicySpec{
+			Predicate: []policy.PredicatePolicy{{Name: "cpu_usage_avg_5m", MaxLimitPecent: 0.65}},
+			Priority:  []policy.PriorityPolicy{{Name: "cpu_usage_avg_5m", Weight: 0.2}},
+			NodePools: []policy.NodePoolPolicy{
+				{
+					Name:         "gpu",
+					NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "gpu"}},
+					Predicate:    []policy.PredicatePolicy{{Name: "cpu_usage_avg_5m", MaxLimitPecent: 0.8}},
+					Priority:     []policy.PriorityPolicy{{Name: "cpu_usage_avg_5m", Weight: 0.5}},
+				},
+			},
+			Profiles: []policy.PolicyProfile{
+				{Name: "batch", Predicate: []policy.PredicatePolicy{{Name: "cpu_usage_avg_5m", MaxLimitPecent: 0.9}}},
+			},
+		},
+	})
+
+	general := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "general"}}
+	gpu := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "gpu", Labels: map[string]string{"pool": "gpu"}}}
+	batchPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{PolicyProfileKey: "batch"}}}
+
+	tests := []struct {
+		name          string
+		pod           *v1.Pod
+		node          *v1.Node
+		wantMaxLimit  float64
+		wantPriWeight float64
+	}{
+		{name: "default", pod: &v1.Pod{}, node: general, wantMaxLimit: 0.65, wantPriWeight: 0.2},
+		{name: "node pool", pod: &v1.Pod{}, node: gpu, wantMaxLimit: 0.8, wantPriWeight: 0.5},
+		{name: "profile", pod: batchPod, node: general, wantMaxLimit: 0.9, wantPriWeight: 0.2},
+		{name: "profile over node pool", pod: batchPod, node: gpu, wantMaxLimit: 0.9, wantPriWeight: 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := ds.getPolicySpec(framework.NewCycleState(), tt.pod, tt.node)
+			if spec.Predicate[0].MaxLimitPecent != tt.wantMaxLimit || spec.Priority[0].Weight != tt.wantPriWeight {
+				t.Errorf("getPolicySpec() = %v, %v, want %v, %v", spec.Predicate[0].MaxLimitPecent, spec.Priority[0].Weight, tt.wantMaxLimit, tt.wantPriWeight)
+			}
+		})
+	}
+}
+
+func TestGetPolicySpecStateCache(t *testing.T) {
+	newPolicy := func(maxLimit float64) *policy.DynamicSchedulerPolicy {
+		return &policy.DynamicSchedulerPolicy{
+			Spec: policy.PolicySpec{
+				Predicate: []policy.PredicatePolicy{{Name: "cpu_usage_avg_5m", MaxLimitPecent: 0.65}},
+				NodePools: []policy.NodePoolPolicy{{Name: "gpu", NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "gpu"}}}},
+				Profiles: []policy.PolicyProfile{
+					{Name: "batch", Predicate: []policy.PredicatePolicy{{Name: "cpu_usage_avg_5m", MaxLimitPecent: maxLimit}}},
+				},
+			},
+		}
+	}
+
+	ds := &DynamicScheduler{}
+	ds.updatePolicy(newPolicy(0.9))
+
+	batchPod := func() *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{PolicyProfileKey: "batch"}}}
+	}
+
+	// pods selecting the same profile share the resolved policies.
+	first := ds.getPolicySpecState(framework.NewCycleState(), batchPod())
+	second := ds.getPolicySpecState(framework.NewCycleState(), batchPod())
+	if first.profileSpecs != second.profileSpecs || first.nodePools != second.nodePools {
+		t.Errorf("policies of profile are resolved for each pod")
+	}
+	if got := first.poolSpecs["gpu"].Predicate[0].MaxLimitPecent; got != 0.9 {
+		t.Errorf("max limit of node pool = %v, want 0.9", got)
+	}
+
+	// the policies are resolved again once policy is updated.
+	ds.updatePolicy(newPolicy(0.8))
+	third := ds.getPolicySpecState(framework.NewCycleState(), batchPod())
+	if third.profileSpecs == first.profileSpecs || third.nodePools == first.nodePools {
+		t.Errorf("policies of profile are not resolved again after policy is updated")
+	}
+	if got := third.spec.Predicate[0].MaxLimitPecent; got != 0.8 {
+		t.Errorf("max limit of profile = %v, want 0.8", got)
+	}
+
+	// undefined profile falls back to the default policy.
+	unknownPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{PolicyProfileKey: "unknown"}}}
+	if s := ds.getPolicySpecState(framework.NewCycleState(), unknownPod); s.found || s.spec.Predicate[0].MaxLimitPecent != 0.65 {
+		t.Errorf("policy of undefined profile = %v, %v, want default", s.found, s.spec.Predicate[0].MaxLimitPecent)
+	}
+}
@@ -0,0 +1,163 @@
+package dynamic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+const (
+	// ThresholdRelaxedReason is the reason of pod events recording the relaxed load thresholds.
+	ThresholdRelaxedReason = "LoadThresholdRelaxed"
+)
+
+// relaxationTracker records how many steps the load thresholds have been relaxed for pending pods.
+type relaxationTracker struct {
+	lock   sync.RWMutex
+	levels map[types.UID]int
+}
+
+func newRelaxationTracker() *relaxationTracker {
+	return &relaxationTracker{
+		levels: map[types.UID]int{},
+	}
+}
+
+func (t *relaxationTracker) get(uid types.UID) int {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.levels[uid]
+}
+
+func (t *relaxationTracker) set(uid types.UID, level int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.levels[uid] = level
+}
+
+func (t *relaxationTracker) delete(uid types.UID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.levels, uid)
+}
+
+// eventHandler forgets pods once they are bound or deleted.
+func (t *relaxationTracker) eventHandler() cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if pod, ok := newObj.(*v1.Pod); ok && pod.Spec.NodeName != "" {
+				t.delete(pod.UID)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			switch o := obj.(type) {
+			case *v1.Pod:
+				t.delete(o.UID)
+			case cache.DeletedFinalStateUnknown:
+				if pod, ok := o.Obj.(*v1.Pod); ok {
+					t.delete(pod.UID)
+				}
+			}
+		},
+	}
+}
+
+// relaxPredicate returns predicatePolicy whose threshold is relaxed by level steps, but not
+// beyond the ceiling.
+func relaxPredicate(predicatePolicy policy.PredicatePolicy, level int) policy.PredicatePolicy {
+	// threshold 0 means the predicate is disabled, which is kept as it is.
+	if predicatePolicy.Relaxation == nil || level <= 0 || predicatePolicy.MaxLimitPecent == 0 {
+		return predicatePolicy
+	}
+
+	threshold := predicatePolicy.MaxLimitPecent + float64(level)*predicatePolicy.Relaxation.Step
+	if threshold > predicatePolicy.Relaxation.Ceiling {
+		threshold = predicatePolicy.Relaxation.Ceiling
+	}
+	predicatePolicy.MaxLimitPecent = threshold
+
+	return predicatePolicy
+}
+
+// getPendingSince returns when the pod became unschedulable, or its creation time.
+func getPendingSince(pod *v1.Pod) time.Time {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionFalse && !condition.LastTransitionTime.IsZero() {
+			return condition.LastTransitionTime.Time
+		}
+	}
+
+	return pod.CreationTimestamp.Time
+}
+
+// PostFilter invoked at the PostFilter extension point.
+// It relaxes the load thresholds for pod by one more step every time the pod has been pending
+// for another relaxation delay, which take effect in the next scheduling attempt. It never
+// makes the pod schedulable by itself. It records the shadow rejections of the scheduling
+// cycle as well.
+func (ds *DynamicScheduler) PostFilter(ctx context.Context, state *framework.CycleState, pod *v1.Pod, filteredNodeStatusMap framework.NodeToStatusMap) (*framework.PostFilterResult, *framework.Status) {
+	// the pod is unschedulable for other plugins in shadow mode.
+	ds.recordShadowRejectionEvent(state, pod)
+
+	if ds.relaxationDelay <= 0 {
+		return nil, framework.NewStatus(framework.Unschedulable, "load threshold relaxation is disabled")
+	}
+
+	rejected := false
+	for _, status := range filteredNodeStatusMap {
+		if status.FailedPlugin() == Name {
+			rejected = true
+			break
+		}
+	}
+	if !rejected {
+		return nil, framework.NewStatus(framework.Unschedulable, "no node is rejected for high load")
+	}
+
+	pendingFor := time.Since(getPendingSince(pod))
+	if pendingFor < ds.relaxationDelay {
+		return nil, framework.NewStatus(framework.Unschedulable, fmt.Sprintf("load thresholds are relaxed after pending for %v", ds.relaxationDelay))
+	}
+
+	level, lastLevel := int(pendingFor/ds.relaxationDelay), ds.relaxations.get(pod.UID)
+	if level <= lastLevel {
+		return nil, framework.NewStatus(framework.Unschedulable, "load thresholds have been relaxed")
+	}
+
+	var relaxed []string
+	for _, predicatePolicy := range ds.getPolicySpecState(state, pod).spec.Predicate {
+		last, current := relaxPredicate(predicatePolicy, lastLevel), relaxPredicate(predicatePolicy, level)
+		if current.MaxLimitPecent != last.MaxLimitPecent {
+			relaxed = append(relaxed, fmt.Sprintf("%s: %.2f", predicatePolicy.Name, current.MaxLimitPecent))
+		}
+	}
+
+	// the level is recorded even if nothing is relaxed, for the predicates of node pools may be.
+	ds.relaxations.set(pod.UID, level)
+
+	if len(relaxed) == 0 {
+		return nil, framework.NewStatus(framework.Unschedulable, "no load threshold can be relaxed")
+	}
+
+	message := fmt.Sprintf("Pod has been pending for %v, relaxed load thresholds to %s", pendingFor.Round(time.Second), strings.Join(relaxed, ", "))
+	klog.V(2).Infof("[crane] pod[%s/%s]: %s", pod.Namespace, pod.Name, message)
+
+	if ds.handle != nil && ds.handle.EventRecorder() != nil {
+		ds.handle.EventRecorder().Eventf(pod, nil, v1.EventTypeNormal, ThresholdRelaxedReason, "Scheduling", message)
+	}
+
+	return nil, framework.NewStatus(framework.Unschedulable, message)
+}
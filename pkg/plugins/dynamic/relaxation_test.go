@@ -0,0 +1,89 @@
+package dynamic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/config"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+)
+
+func TestThresholdRelaxation(t *testing.T) {
+	ds := &DynamicScheduler{relaxationDelay: 5 * time.Minute, relaxations: newRelaxationTracker()}
+	ds.updatePolicy(&policy.DynamicSchedulerPolicy{
+		Spec: policy.PolicySpec{
+			SyncPeriod: []policy.SyncPolicy{
+				{Name: "cpu_usage_avg_5m", Period: metav1.Duration{Duration: 3 * time.Minute}},
+			},
+			Predicate: []policy.PredicatePolicy{
+				{Name: "cpu_usage_avg_5m", MaxLimitPecent: 0.65, Relaxation: &policy.ThresholdRelaxation{Step: 0.05, Ceiling: 0.8}},
+			},
+		},
+	})
+
+	nodeInfo := framework.NewNodeInfo()
+	nodeInfo.SetNode(newAnnotatedNode("node1", "1", 0.72))
+	rejected := framework.NodeToStatusMap{"node1": framework.NewStatus(framework.Unschedulable).WithFailedPlugin(Name)}
+
+	tests := []struct {
+		name        string
+		pendingFor  time.Duration
+		wantLevel   int
+		wantSuccess bool
+	}{
+		{name: "not pending long enough", pendingFor: time.Minute, wantLevel: 0, wantSuccess: false},
+		{name: "relaxed by one step", pendingFor: 6 * time.Minute, wantLevel: 1, wantSuccess: false},
+		{name: "relaxed by two steps", pendingFor: 11 * time.Minute, wantLevel: 2, wantSuccess: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", UID: "pod", CreationTimestamp: metav1.NewTime(time.Now().Add(-tt.pendingFor))},
+			}
+
+			_, status := ds.PostFilter(context.TODO(), framework.NewCycleState(), pod, rejected)
+			if status.IsSuccess() {
+				t.Errorf("PostFilter() should never succeed")
+			}
+
+			if got := ds.relaxations.get(pod.UID); got != tt.wantLevel {
+				t.Errorf("relaxation level = %d, want %d", got, tt.wantLevel)
+			}
+
+			if got := ds.Filter(context.TODO(), framework.NewCycleState(), pod, nodeInfo).IsSuccess(); got != tt.wantSuccess {
+				t.Errorf("Filter() success = %v, want %v", got, tt.wantSuccess)
+			}
+		})
+	}
+
+	// the ceiling is never exceeded.
+	if got := relaxPredicate(ds.getPolicy().Spec.Predicate[0], 10).MaxLimitPecent; got != 0.8 {
+		t.Errorf("relaxed threshold = %v, want 0.8", got)
+	}
+}
+
+func TestThresholdRelaxationDisabled(t *testing.T) {
+	args := &config.DynamicArgs{ThresholdRelaxationDelay: metav1.Duration{Duration: -time.Minute}}
+	if _, err := NewDynamicScheduler(args, nil); err == nil {
+		t.Errorf("NewDynamicScheduler() with negative thresholdRelaxationDelay succeeded, want error")
+	}
+
+	ds := &DynamicScheduler{relaxations: newRelaxationTracker()}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", UID: "pod", CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour))},
+	}
+	rejected := framework.NodeToStatusMap{"node1": framework.NewStatus(framework.Unschedulable).WithFailedPlugin(Name)}
+
+	if _, status := ds.PostFilter(context.TODO(), framework.NewCycleState(), pod, rejected); status.IsSuccess() {
+		t.Errorf("PostFilter() should never succeed")
+	}
+	if got := ds.relaxations.get(pod.UID); got != 0 {
+		t.Errorf("relaxation level = %d with relaxation disabled, want 0", got)
+	}
+}
@@ -0,0 +1,137 @@
+package dynamic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+const (
+	// ShadowFilterRejectedReason is the reason of pod events recording the nodes which would
+	// have been rejected by Filter in shadow mode.
+	ShadowFilterRejectedReason = "ShadowFilterRejected"
+
+	shadowRejectionsStateKey framework.StateKey = Name + "/shadow-rejections"
+
+	// maxShadowRejectionEventNodes is the max number of node names listed in a shadow rejection event.
+	maxShadowRejectionEventNodes = 5
+)
+
+// shadowRejectionsState collects the nodes which would have been rejected for pod in a scheduling
+// cycle, which are recorded as a single event at the end of the cycle.
+type shadowRejectionsState struct {
+	lock sync.Mutex
+	// nodes maps node names to the metrics they are overloaded in.
+	nodes    map[string][]string
+	recorded bool
+}
+
+// Clone returns the state itself, so that the rejections found in any copy of the cycle state
+// are recorded once.
+func (s *shadowRejectionsState) Clone() framework.StateData {
+	return s
+}
+
+func (s *shadowRejectionsState) add(nodeName string, metrics []string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.nodes[nodeName] = metrics
+}
+
+// summary returns the message of rejections, it returns false if there is no rejection or the
+// rejections have been recorded.
+func (s *shadowRejectionsState) summary() (string, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.recorded || len(s.nodes) == 0 {
+		return "", false
+	}
+	s.recorded = true
+
+	nodeNames := make([]string, 0, len(s.nodes))
+	nodesOfMetric := map[string]int{}
+	for nodeName, metrics := range s.nodes {
+		nodeNames = append(nodeNames, nodeName)
+		for _, metric := range metrics {
+			nodesOfMetric[metric]++
+		}
+	}
+	sort.Strings(nodeNames)
+
+	metrics := make([]string, 0, len(nodesOfMetric))
+	for metric, count := range nodesOfMetric {
+		metrics = append(metrics, fmt.Sprintf("%s on %d", metric, count))
+	}
+	sort.Strings(metrics)
+
+	if len(nodeNames) > maxShadowRejectionEventNodes {
+		nodeNames = append(nodeNames[:maxShadowRejectionEventNodes], "...")
+	}
+
+	return fmt.Sprintf("%d node(s) would have been rejected by %s, load[%s] is too high: %s",
+		len(s.nodes), Name, strings.Join(metrics, ", "), strings.Join(nodeNames, ", ")), true
+}
+
+// getShadowRejectionsState returns the shadow rejections of the scheduling cycle, which is
+// created on the first rejection.
+func (ds *DynamicScheduler) getShadowRejectionsState(state *framework.CycleState, create bool) *shadowRejectionsState {
+	// Filter runs in parallel for nodes, which must share the same state.
+	ds.shadowStateLock.Lock()
+	defer ds.shadowStateLock.Unlock()
+
+	if data, err := state.Read(shadowRejectionsStateKey); err == nil {
+		if s, ok := data.(*shadowRejectionsState); ok {
+			return s
+		}
+	}
+
+	if !create {
+		return nil
+	}
+
+	s := &shadowRejectionsState{nodes: map[string][]string{}}
+	state.Write(shadowRejectionsStateKey, s)
+
+	return s
+}
+
+// recordShadowRejection records that node would have been rejected for pod because it is
+// overloaded in metrics, in shadow mode. The events of pod are recorded at the end of the
+// scheduling cycle by recordShadowRejectionEvent.
+func (ds *DynamicScheduler) recordShadowRejection(state *framework.CycleState, pod *v1.Pod, nodeName string, metrics []string) {
+	for _, metric := range metrics {
+		shadowFilterRejections.WithLabelValues(metric, nodeName).Inc()
+	}
+
+	klog.V(4).Infof("[crane] node[%s] would have been rejected for pod[%s/%s] in shadow mode, load[%s] is too high",
+		nodeName, pod.Namespace, pod.Name, strings.Join(metrics, ","))
+
+	if ds.shadowModeEvents {
+		ds.getShadowRejectionsState(state, true).add(nodeName, metrics)
+	}
+}
+
+// recordShadowRejectionEvent records the nodes which would have been rejected for pod in the
+// scheduling cycle as a single event, which is called at Reserve if pod is schedulable, or at
+// PostFilter otherwise.
+func (ds *DynamicScheduler) recordShadowRejectionEvent(state *framework.CycleState, pod *v1.Pod) {
+	if !ds.shadowModeEvents || ds.handle == nil || ds.handle.EventRecorder() == nil {
+		return
+	}
+
+	s := ds.getShadowRejectionsState(state, false)
+	if s == nil {
+		return
+	}
+
+	if message, ok := s.summary(); ok {
+		ds.handle.EventRecorder().Eventf(pod, nil, v1.EventTypeNormal, ShadowFilterRejectedReason, "Scheduling", message)
+	}
+}
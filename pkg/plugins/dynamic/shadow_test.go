@@ -0,0 +1,112 @@
+package dynamic
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/component-base/metrics/testutil"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/loader"
+)
+
+// fakeHandle provides the event recorder of framework.Handle only.
+type fakeHandle struct {
+	framework.Handle
+	recorder events.EventRecorder
+}
+
+func (h *fakeHandle) EventRecorder() events.EventRecorder {
+	return h.recorder
+}
+
+func TestShadowModeFilter(t *testing.T) {
+	RegisterMetrics()
+
+	schedulerPolicy, err := loader.LoadPolicyFromFile(filepath.Join("..", "..", "..", "deploy", "manifests", "dynamic", "policy.yaml"))
+	if err != nil {
+		t.Fatalf("failed to load policy: %v", err)
+	}
+
+	overloaded, idle := framework.NewNodeInfo(), framework.NewNodeInfo()
+	overloaded.SetNode(newAnnotatedNode("shadow-overloaded", "1", 0.9))
+	idle.SetNode(newAnnotatedNode("shadow-idle", "1", 0.1))
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod"}}
+
+	for _, shadowMode := range []bool{false, true} {
+		ds := &DynamicScheduler{shadowMode: shadowMode}
+		ds.updatePolicy(schedulerPolicy)
+
+		status := ds.Filter(context.TODO(), framework.NewCycleState(), pod, overloaded)
+		if status.IsSuccess() != shadowMode {
+			t.Errorf("Filter() in shadow mode %v = %v, want success %v", shadowMode, status, shadowMode)
+		}
+		if status := ds.Filter(context.TODO(), framework.NewCycleState(), pod, idle); !status.IsSuccess() {
+			t.Errorf("Filter() of idle node in shadow mode %v = %v, want success", shadowMode, status)
+		}
+	}
+
+	// every predicate is evaluated in shadow mode, while only the first one rejects the node otherwise.
+	// rejections are counted per metric and node.
+	for _, metric := range []string{"cpu_usage_avg_5m", "cpu_usage_max_avg_1h", "mem_usage_avg_5m", "mem_usage_max_avg_1h"} {
+		for node, want := range map[string]float64{"shadow-overloaded": 1, "shadow-idle": 0} {
+			value, err := testutil.GetCounterMetricValue(shadowFilterRejections.WithLabelValues(metric, node))
+			if err != nil || value != want {
+				t.Errorf("shadow rejections of %s on %s = %v, %v, want %v", metric, node, value, err, want)
+			}
+		}
+	}
+}
+
+func TestShadowModeEvents(t *testing.T) {
+	RegisterMetrics()
+
+	schedulerPolicy, err := loader.LoadPolicyFromFile(filepath.Join("..", "..", "..", "deploy", "manifests", "dynamic", "policy.yaml"))
+	if err != nil {
+		t.Fatalf("failed to load policy: %v", err)
+	}
+
+	recorder := events.NewFakeRecorder(100)
+	ds := &DynamicScheduler{shadowMode: true, shadowModeEvents: true, handle: &fakeHandle{recorder: recorder}}
+	ds.updatePolicy(schedulerPolicy)
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod"}}
+	state := framework.NewCycleState()
+
+	for i := 0; i < 10; i++ {
+		nodeInfo := framework.NewNodeInfo()
+		nodeInfo.SetNode(newAnnotatedNode(fmt.Sprintf("node%d", i), "1", 0.9))
+		if status := ds.Filter(context.TODO(), state, pod, nodeInfo); !status.IsSuccess() {
+			t.Fatalf("Filter() in shadow mode = %v, want success", status)
+		}
+	}
+	if len(recorder.Events) != 0 {
+		t.Fatalf("events are recorded by Filter")
+	}
+
+	// a single event is recorded for the scheduling cycle, however many nodes are rejected.
+	ds.Reserve(context.TODO(), state, pod, "node0")
+	ds.PostFilter(context.TODO(), state, pod, framework.NodeToStatusMap{})
+	if len(recorder.Events) != 1 {
+		t.Fatalf("got %d events, want 1", len(recorder.Events))
+	}
+
+	event := <-recorder.Events
+	for _, want := range []string{ShadowFilterRejectedReason, "10 node(s)", "cpu_usage_avg_5m on 10", "node0, node1, node2, node3, node4, ..."} {
+		if !strings.Contains(event, want) {
+			t.Errorf("event %q does not contain %q", event, want)
+		}
+	}
+
+	// no event is recorded for the cycle without rejections.
+	ds.Reserve(context.TODO(), framework.NewCycleState(), pod, "node0")
+	if len(recorder.Events) != 0 {
+		t.Errorf("event is recorded without rejections")
+	}
+}
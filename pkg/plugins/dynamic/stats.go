@@ -2,17 +2,13 @@ package dynamic
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
+	"math"
 	"time"
 
-	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 
 	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
-
-	"github.com/gocrane/crane-scheduler/pkg/utils"
 )
 
 const (
@@ -26,76 +22,150 @@ const (
 	ExtraActivePeriod = 5 * time.Minute
 )
 
-// inActivePeriod judges if node annotation with this timestamp is effective.
-func inActivePeriod(updatetimeStr string, activeDuration time.Duration) bool {
-	if len(updatetimeStr) < MinTimestampStrLength {
-		klog.Errorf("[crane] illegel timestamp: %s", updatetimeStr)
-		return false
-	}
+// loadDataState tells whether the load data of a metric is usable.
+type loadDataState string
+
+const (
+	loadDataFresh   loadDataState = "fresh"
+	loadDataExpired loadDataState = "expired"
+	loadDataMissing loadDataState = "missing"
+)
 
-	originUpdateTime, err := time.ParseInLocation(utils.TimeFormat, updatetimeStr, utils.GetLocation())
+const (
+	// defaultPredicateMissingData passes the predicate for nodes without load data.
+	defaultPredicateMissingData = policy.MissingDataIgnoreNode
+	// defaultPriorityMissingData gives no score to nodes without load data.
+	defaultPriorityMissingData = policy.MissingDataTreatAsOverloaded
+)
+
+// inActivePeriod judges if load data updated at this time is effective.
+func inActivePeriod(updateTime time.Time, activeDuration time.Duration) bool {
+	return time.Now().Before(updateTime.Add(activeDuration))
+}
+
+// readResourceUsage reads the usage of key, the value is returned even if it is expired.
+func readResourceUsage(source loadSource, key string, activeDuration time.Duration) (float64, loadDataState, error) {
+	usedValue, updateTime, err := source.getLoad(key)
 	if err != nil {
-		klog.Errorf("[crane] failed to parse timestamp: %v", err)
-		return false
+		return 0, loadDataMissing, err
 	}
 
-	now, updatetime := time.Now(), originUpdateTime.Add(activeDuration)
+	if usedValue < 0 {
+		return 0, loadDataMissing, fmt.Errorf("illegel value of %s: %f", key, usedValue)
+	}
 
-	if now.Before(updatetime) {
-		return true
+	if !inActivePeriod(updateTime, activeDuration) {
+		return usedValue, loadDataExpired, fmt.Errorf("timestamp[%s] of %s is expired", updateTime.Format(time.RFC3339), key)
 	}
 
-	return false
+	return usedValue, loadDataFresh, nil
 }
 
-func getResourceUsage(anno map[string]string, key string, activeDuration time.Duration) (float64, error) {
-	usedstr, ok := anno[key]
-	if !ok {
-		return 0, fmt.Errorf("key[%s] not found", usedstr)
-	}
-
-	usedSlice := strings.Split(usedstr, ",")
-	if len(usedSlice) != 2 {
-		return 0, fmt.Errorf("illegel value: %s", usedstr)
+func getResourceUsage(source loadSource, key string, activeDuration time.Duration) (float64, error) {
+	usedValue, state, err := readResourceUsage(source, key, activeDuration)
+	if state != loadDataFresh {
+		return 0, err
 	}
 
-	if !inActivePeriod(usedSlice[1], activeDuration) {
-		return 0, fmt.Errorf("timestamp[%s] is expired", usedstr)
-	}
+	return usedValue, nil
+}
 
-	UsedValue, err := strconv.ParseFloat(usedSlice[0], 64)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse float[%s]", usedSlice[0])
+// resolveMissingData returns the policy applied when the load data is missing or expired,
+// UseLastKnown falls back to IgnoreNode if there is no value at all.
+func resolveMissingData(missingData, defaultPolicy policy.MissingDataPolicy, state loadDataState) policy.MissingDataPolicy {
+	if missingData == "" {
+		missingData = defaultPolicy
 	}
 
-	if UsedValue < 0 {
-		return 0, fmt.Errorf("illegel value: %s", usedstr)
+	if missingData == policy.MissingDataUseLastKnown && state != loadDataExpired {
+		return policy.MissingDataIgnoreNode
 	}
 
-	return UsedValue, nil
+	return missingData
 }
 
-func getScore(anno map[string]string, priorityPolicy policy.PriorityPolicy, syncPeriod []policy.SyncPolicy) (float64, error) {
+// getScore returns the score of node according to priorityPolicy, and false if the priority
+// does not apply to the node.
+func getScore(source loadSource, priorityPolicy policy.PriorityPolicy, syncPeriod []policy.SyncPolicy) (float64, bool, error) {
 	activeDuration, err := getActiveDuration(syncPeriod, priorityPolicy.Name)
 	if err != nil || activeDuration == 0 {
-		return 0, fmt.Errorf("failed to get the active duration of resource[%s]: %v, while the actual value is %v", priorityPolicy.Name, err, activeDuration)
+		return 0, true, fmt.Errorf("failed to get the active duration of resource[%s]: %v, while the actual value is %v", priorityPolicy.Name, err, activeDuration)
 	}
 
-	usage, err := getResourceUsage(anno, priorityPolicy.Name, activeDuration)
-	if err != nil {
-		return 0, err
+	usage, state, err := readResourceUsage(source, priorityPolicy.Name, activeDuration)
+	if state != loadDataFresh {
+		switch resolveMissingData(priorityPolicy.MissingData, defaultPriorityMissingData, state) {
+		case policy.MissingDataIgnoreNode:
+			return 0, false, err
+		case policy.MissingDataTreatAsIdle:
+			usage, err = 0, nil
+		case policy.MissingDataUseLastKnown:
+			err = nil
+		default:
+			return 0, true, err
+		}
 	}
 
 	score := (1. - usage) * priorityPolicy.Weight * float64(framework.MaxNodeScore)
 
-	return score, nil
+	return score, true, err
 }
 
-func isOverLoad(name string, anno map[string]string, predicatePolicy policy.PredicatePolicy, activeDuration time.Duration) bool {
-	usage, err := getResourceUsage(anno, predicatePolicy.Name, activeDuration)
-	if err != nil {
-		klog.Errorf("[crane] can not get the usage of resource[%s] from node[%s]'s annotation: %v", predicatePolicy.Name, name, err)
-		return false
+// getBalanceScore returns the score of node according to how balanced the usage of metrics in
+// balancePolicy is, and false if the usage of any metric is unavailable.
+func getBalanceScore(source loadSource, balancePolicy policy.BalancePolicy, syncPeriod []policy.SyncPolicy) (float64, bool, error) {
+	usages := make([]float64, 0, len(balancePolicy.Metrics))
+	for _, name := range balancePolicy.Metrics {
+		activeDuration, err := getActiveDuration(syncPeriod, name)
+		if err != nil || activeDuration == 0 {
+			return 0, false, fmt.Errorf("failed to get the active duration of resource[%s]: %v, while the actual value is %v", name, err, activeDuration)
+		}
+
+		usage, err := getResourceUsage(source, name, activeDuration)
+		if err != nil {
+			return 0, false, err
+		}
+		usages = append(usages, usage)
+	}
+
+	if len(usages) == 0 {
+		return 0, false, nil
+	}
+
+	var mean, variance float64
+	for _, usage := range usages {
+		mean += usage
+	}
+	mean /= float64(len(usages))
+	for _, usage := range usages {
+		variance += (usage - mean) * (usage - mean)
+	}
+	variance /= float64(len(usages))
+
+	// the same as NodeResourcesBalancedAllocation, the score decreases with the standard deviation of usage.
+	balance := 1. - math.Sqrt(variance)
+	if balance < 0 {
+		balance = 0
+	}
+
+	return balance * balancePolicy.Weight * float64(framework.MaxNodeScore), true, nil
+}
+
+func isOverLoad(name string, source loadSource, predicatePolicy policy.PredicatePolicy, activeDuration time.Duration) bool {
+	usage, state, err := readResourceUsage(source, predicatePolicy.Name, activeDuration)
+	if state != loadDataFresh {
+		switch resolveMissingData(predicatePolicy.MissingData, defaultPredicateMissingData, state) {
+		case policy.MissingDataTreatAsOverloaded:
+			klog.V(4).Infof("[crane] node[%s] is treated as overloaded for the usage of resource[%s]: %v", name, predicatePolicy.Name, err)
+			return true
+		case policy.MissingDataUseLastKnown:
+			klog.V(4).Infof("[crane] use the last known usage of resource[%s] from node[%s]: %v", predicatePolicy.Name, name, err)
+		case policy.MissingDataTreatAsIdle:
+			return false
+		default:
+			klog.Errorf("[crane] can not get the usage of resource[%s] from node[%s]: %v", predicatePolicy.Name, name, err)
+			return false
+		}
 	}
 
 	// threshold was set as 0 means that the filter according to this metric is useless.
@@ -111,9 +181,9 @@ func isOverLoad(name string, anno map[string]string, predicatePolicy policy.Pred
 	return false
 }
 
-func getNodeScore(name string, anno map[string]string, policySpec policy.PolicySpec) int {
+func getNodeScore(name string, source loadSource, policySpec policy.PolicySpec) int {
 
-	lenPriorityPolicyList := len(policySpec.Priority)
+	lenPriorityPolicyList := len(policySpec.Priority) + len(policySpec.Balance)
 	if lenPriorityPolicyList == 0 {
 		klog.Warningf("[crane] no priority policy exists, all nodes scores 0.")
 		return 0
@@ -123,15 +193,38 @@ func getNodeScore(name string, anno map[string]string, policySpec policy.PolicyS
 
 	for _, priorityPolicy := range policySpec.Priority {
 
-		priorityScore, err := getScore(anno, priorityPolicy, policySpec.SyncPeriod)
+		priorityScore, counted, err := getScore(source, priorityPolicy, policySpec.SyncPeriod)
 		if err != nil {
-			klog.Errorf("[crane] failed to get node 's score: %v", name, priorityPolicy.Name, score)
+			klog.Errorf("[crane] failed to get node[%s]'s score of resource[%s]: %v", name, priorityPolicy.Name, err)
+		}
+
+		if !counted {
+			continue
 		}
 
 		weight += priorityPolicy.Weight
 		score += priorityScore
 	}
 
+	for _, balancePolicy := range policySpec.Balance {
+
+		balanceScore, counted, err := getBalanceScore(source, balancePolicy, policySpec.SyncPeriod)
+		if err != nil {
+			klog.V(4).Infof("[crane] failed to get node[%s]'s balance score of [%s]: %v", name, balancePolicy.Name, err)
+		}
+
+		if !counted {
+			continue
+		}
+
+		weight += balancePolicy.Weight
+		score += balanceScore
+	}
+
+	if weight == 0 {
+		return 0
+	}
+
 	finnalScore := int(score / weight)
 
 	return finnalScore
@@ -149,18 +242,15 @@ func getActiveDuration(syncPeriodList []policy.SyncPolicy, name string) (time.Du
 	return 0, fmt.Errorf("failed to get the active duration")
 }
 
-func getNodeHotValue(node *v1.Node) float64 {
-	anno := node.ObjectMeta.Annotations
-	if anno == nil {
-		return 0
-	}
-
-	hotvalue, err := getResourceUsage(anno, NodeHotValue, DefautlHotVauleActivePeriod)
-	if err != nil {
-		return 0
+// getNodeHotValue returns the hot value of node and the time when it was updated, which is
+// zero if the hot value is unavailable.
+func getNodeHotValue(name string, source loadSource) (float64, time.Time) {
+	hotvalue, updateTime, err := source.getLoad(NodeHotValue)
+	if err != nil || hotvalue < 0 || !inActivePeriod(updateTime, DefautlHotVauleActivePeriod) {
+		return 0, time.Time{}
 	}
 
-	klog.V(4).Infof("[crane] Node[%s]'s hotvalue is %f\n", node.Name, hotvalue)
+	klog.V(4).Infof("[crane] Node[%s]'s hotvalue is %f\n", name, hotvalue)
 
-	return hotvalue
+	return hotvalue, updateTime
 }
@@ -0,0 +1,165 @@
+package dynamic
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/component-base/metrics/testutil"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+	"github.com/gocrane/crane-scheduler/pkg/utils"
+)
+
+func TestMissingDataPolicy(t *testing.T) {
+	expired := utils.FormatTimestamp(time.Now().Add(-time.Hour))
+	source := annotationLoadSource{
+		"cpu_usage_avg_5m": "0.75000," + expired,
+	}
+	syncPeriod := []policy.SyncPolicy{
+		{Name: "cpu_usage_avg_5m", Period: metav1.Duration{Duration: 3 * time.Minute}},
+		{Name: "mem_usage_avg_5m", Period: metav1.Duration{Duration: 3 * time.Minute}},
+	}
+	activeDuration, _ := getActiveDuration(syncPeriod, "cpu_usage_avg_5m")
+
+	tests := []struct {
+		missingData policy.MissingDataPolicy
+		// overloaded is the result of predicate on expired cpu usage.
+		overloaded bool
+		// score is the node score, where cpu usage is expired and memory usage is missing.
+		score int
+	}{
+		{missingData: "", overloaded: false, score: 0},
+		{missingData: policy.MissingDataIgnoreNode, overloaded: false, score: 0},
+		{missingData: policy.MissingDataTreatAsOverloaded, overloaded: true, score: 0},
+		{missingData: policy.MissingDataTreatAsIdle, overloaded: false, score: 100},
+		// memory usage is ignored as it has never been known.
+		{missingData: policy.MissingDataUseLastKnown, overloaded: true, score: 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.missingData), func(t *testing.T) {
+			predicate := policy.PredicatePolicy{Name: "cpu_usage_avg_5m", MaxLimitPecent: 0.65, MissingData: tt.missingData}
+			if got := isOverLoad("node1", source, predicate, activeDuration); got != tt.overloaded {
+				t.Errorf("isOverLoad() = %v, want %v", got, tt.overloaded)
+			}
+
+			spec := policy.PolicySpec{
+				SyncPeriod: syncPeriod,
+				Priority: []policy.PriorityPolicy{
+					{Name: "cpu_usage_avg_5m", Weight: 0.5, MissingData: tt.missingData},
+					{Name: "mem_usage_avg_5m", Weight: 0.5, MissingData: tt.missingData},
+				},
+			}
+			if got := getNodeScore("node1", source, spec); got != tt.score {
+				t.Errorf("getNodeScore() = %v, want %v", got, tt.score)
+			}
+		})
+	}
+}
+
+func TestBalanceScore(t *testing.T) {
+	now := utils.FormatTimestamp(time.Now())
+	syncPeriod := []policy.SyncPolicy{
+		{Name: "cpu_usage_avg_5m", Period: metav1.Duration{Duration: 3 * time.Minute}},
+		{Name: "mem_usage_avg_5m", Period: metav1.Duration{Duration: 3 * time.Minute}},
+	}
+	spec := policy.PolicySpec{
+		SyncPeriod: syncPeriod,
+		Priority: []policy.PriorityPolicy{
+			{Name: "cpu_usage_avg_5m", Weight: 0.5},
+			{Name: "mem_usage_avg_5m", Weight: 0.5},
+		},
+		Balance: []policy.BalancePolicy{
+			{Name: "cpu-mem", Metrics: []string{"cpu_usage_avg_5m", "mem_usage_avg_5m"}, Weight: 1},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		source annotationLoadSource
+		score  int
+	}{
+		{
+			name:   "balanced",
+			source: annotationLoadSource{"cpu_usage_avg_5m": "0.50000," + now, "mem_usage_avg_5m": "0.50000," + now},
+			score:  75,
+		},
+		{
+			name:   "imbalanced",
+			source: annotationLoadSource{"cpu_usage_avg_5m": "0.25000," + now, "mem_usage_avg_5m": "0.75000," + now},
+			score:  62,
+		},
+		{
+			// balance is not counted without memory usage, while memory priority scores 0.
+			name:   "missing memory usage",
+			source: annotationLoadSource{"cpu_usage_avg_5m": "0.50000," + now},
+			score:  25,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getNodeScore("node1", tt.source, spec); got != tt.score {
+				t.Errorf("getNodeScore() = %v, want %v", got, tt.score)
+			}
+		})
+	}
+}
+
+func TestRecordLoadDataStates(t *testing.T) {
+	RegisterMetrics()
+
+	now := utils.FormatTimestamp(time.Now())
+	expired := utils.FormatTimestamp(time.Now().Add(-time.Hour))
+	nodes := []*v1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "fresh", Annotations: map[string]string{"cpu_usage_avg_5m": "0.50000," + now}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "expired", Annotations: map[string]string{"cpu_usage_avg_5m": "0.50000," + expired}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "missing"}},
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, node := range nodes {
+		indexer.Add(node)
+	}
+
+	ds := &DynamicScheduler{}
+	ds.updatePolicy(&policy.DynamicSchedulerPolicy{Spec: policy.PolicySpec{
+		SyncPeriod: []policy.SyncPolicy{{Name: "cpu_usage_avg_5m", Period: metav1.Duration{Duration: 3 * time.Minute}}},
+		Predicate:  []policy.PredicatePolicy{{Name: "cpu_usage_avg_5m", MaxLimitPecent: 0.65, MissingData: policy.MissingDataUseLastKnown}},
+		Priority:   []policy.PriorityPolicy{{Name: "cpu_usage_avg_5m", Weight: 1}},
+	}})
+	ds.recordLoadDataStates(corelisters.NewNodeLister(indexer))
+
+	for _, tt := range []struct {
+		labels []string
+		want   float64
+	}{
+		{labels: []string{"cpu_usage_avg_5m", "fresh"}, want: 1},
+		{labels: []string{"cpu_usage_avg_5m", "expired"}, want: 1},
+		{labels: []string{"cpu_usage_avg_5m", "missing"}, want: 1},
+	} {
+		if got, err := testutil.GetGaugeMetricValue(loadDataNodes.WithLabelValues(tt.labels...)); err != nil || got != tt.want {
+			t.Errorf("load_data_nodes%v = %v, %v, want %v", tt.labels, got, err, tt.want)
+		}
+	}
+
+	// UseLastKnown falls back to IgnoreNode for the node never reporting data, while nodes
+	// without data get no score by default.
+	for _, tt := range []struct {
+		labels []string
+		want   float64
+	}{
+		{labels: []string{"Filter", "cpu_usage_avg_5m", "UseLastKnown"}, want: 1},
+		{labels: []string{"Filter", "cpu_usage_avg_5m", "IgnoreNode"}, want: 1},
+		{labels: []string{"Filter", "cpu_usage_avg_5m", "TreatAsOverloaded"}, want: 0},
+		{labels: []string{"Score", "cpu_usage_avg_5m", "TreatAsOverloaded"}, want: 2},
+		{labels: []string{"Score", "cpu_usage_avg_5m", "IgnoreNode"}, want: 0},
+	} {
+		if got, err := testutil.GetGaugeMetricValue(missingDataNodes.WithLabelValues(tt.labels...)); err != nil || got != tt.want {
+			t.Errorf("missing_data_nodes%v = %v, %v, want %v", tt.labels, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package noderesourcetopology
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	craneclientset "github.com/gocrane/crane-scheduler/pkg/generated/clientset/versioned"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/config"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/helper"
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy/loader"
+)
+
+// initExemptions loads the policy of Dynamic plugin and keeps its exemption rules up to date.
+// There are no exemption rules if neither the policy file nor object is specified.
+func (tm *TopologyMatch) initExemptions(cfg *config.NodeResourceTopologyMatchArgs, handle framework.Handle) error {
+	// the policy is watched once per process, and shared by the plugins of all profiles.
+	var schedulerPolicy *policy.DynamicSchedulerPolicy
+	var err error
+	switch {
+	case cfg.PolicyName != "":
+		client, err := craneclientset.NewForConfig(handle.KubeConfig())
+		if err != nil {
+			return fmt.Errorf("failed to create crane clientset: %v", err)
+		}
+		schedulerPolicy, tm.cancelPolicy, err = loader.SubscribePolicyObject(client, cfg.PolicyName, tm.updateExemptions)
+		if err != nil {
+			return err
+		}
+	case cfg.PolicyConfigPath != "":
+		schedulerPolicy, tm.cancelPolicy, err = loader.SubscribePolicyFile(cfg.PolicyConfigPath, tm.updateExemptions)
+		if err != nil {
+			return fmt.Errorf("failed to get scheduler policy from config file: %v", err)
+		}
+	default:
+		return nil
+	}
+	// newer rules may have been applied by the handler.
+	tm.exemptions.CompareAndSwap(nil, schedulerPolicy.Spec.Exemptions)
+
+	return nil
+}
+
+func (tm *TopologyMatch) updateExemptions(p *policy.DynamicSchedulerPolicy) {
+	klog.V(4).InfoS("Update exemption rules", "exemptions", p.Spec.Exemptions)
+	tm.exemptions.Store(p.Spec.Exemptions)
+}
+
+// isPodExempted returns true if pod matches the exemption rules of policy.
+func (tm *TopologyMatch) isPodExempted(pod *corev1.Pod) bool {
+	rules, _ := tm.exemptions.Load().(*policy.ExemptionRules)
+	return helper.IsPodExempted(pod, rules)
+}
@@ -29,6 +29,7 @@ func (tm *TopologyMatch) PreFilter(
 	resources := computeContainerSpecifiedResourceRequest(pod, indices, tm.topologyAwareResources)
 	state.Write(stateKey, &stateData{
 		aware:                   IsPodAwareOfTopology(pod.Annotations),
+		exempted:                tm.isPodExempted(pod),
 		targetContainerIndices:  indices,
 		targetContainerResource: resources,
 		podTopologyByNode:       make(map[string]*nodeWrapper),
@@ -57,7 +58,7 @@ func (tm *TopologyMatch) Filter(
 		return framework.NewStatus(framework.Error, "node(s) not found")
 	}
 
-	if utils.IsDaemonsetPod(pod) || len(s.targetContainerIndices) == 0 {
+	if utils.IsDaemonsetPod(pod) || s.exempted || len(s.targetContainerIndices) == 0 {
 		return nil
 	}
 
@@ -18,6 +18,8 @@ import (
 
 	"github.com/gocrane/api/pkg/generated/clientset/versioned/fake"
 	topologyv1alpha1 "github.com/gocrane/api/topology/v1alpha1"
+
+	"github.com/gocrane/crane-scheduler/pkg/plugins/apis/policy"
 )
 
 var (
@@ -158,6 +160,7 @@ func TestTopologyMatch_Filter(t *testing.T) {
 		nrt                    *topologyv1alpha1.NodeResourceTopology
 		assumedPods            []*assumedPod
 		topologyAwareResources sets.String
+		exemptions             *policy.ExemptionRules
 	}
 	tests := []struct {
 		name string
@@ -210,6 +213,26 @@ func TestTopologyMatch_Filter(t *testing.T) {
 			},
 			want: framework.NewStatus(framework.Unschedulable, ErrReasonNUMAResourceNotEnough),
 		},
+		{
+			name: "no enough cpu resource for exempted pod",
+			args: args{
+				pod: func() *corev1.Pod {
+					pod := newResourcePod(true, nil, framework.Resource{MilliCPU: CPUTestUnit, Memory: MemTestUnit})
+					pod.Namespace = "kube-system"
+					return pod
+				}(),
+				nodeInfo: framework.NewNodeInfo(
+					newResourcePod(true, newZoneList([]zone{{name: "node1", cpu: 2 * CPUTestUnit}}),
+						framework.Resource{MilliCPU: 2 * CPUTestUnit, Memory: 2 * MemTestUnit}),
+					newResourcePod(true, newZoneList([]zone{{name: "node2", cpu: 4 * CPUTestUnit}}),
+						framework.Resource{MilliCPU: 4 * CPUTestUnit, Memory: 1 * MemTestUnit}),
+				),
+				nrt:                    nrt,
+				topologyAwareResources: sets.NewString(string(corev1.ResourceCPU)),
+				exemptions:             &policy.ExemptionRules{Namespaces: []string{"kube-system"}},
+			},
+			want: nil,
+		},
 		{
 			name: "no enough cpu resource in one NUMA node",
 			args: args{
@@ -381,11 +404,15 @@ func TestTopologyMatch_Filter(t *testing.T) {
 				}
 			}
 
-			var p framework.Plugin = &TopologyMatch{
+			tm := &TopologyMatch{
 				lister:                 lister,
 				PodTopologyCache:       cache,
 				topologyAwareResources: tt.args.topologyAwareResources,
 			}
+			if tt.args.exemptions != nil {
+				tm.exemptions.Store(tt.args.exemptions)
+			}
+			var p framework.Plugin = tm
 			cycleState := framework.NewCycleState()
 			preFilterStatus := p.(framework.PreFilterPlugin).PreFilter(ctx, cycleState, tt.args.pod)
 			if !preFilterStatus.IsSuccess() {
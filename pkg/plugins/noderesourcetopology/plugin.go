@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -28,35 +29,75 @@ const (
 )
 
 // New initializes a new plugin and returns it.
-func New(args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+func New(args runtime.Object, handle framework.Handle) (_ framework.Plugin, err error) {
 	klog.V(2).InfoS("Creating new TopologyMatch plugin")
 	cfg, ok := args.(*config.NodeResourceTopologyMatchArgs)
 	if !ok {
 		return nil, fmt.Errorf("want args to be of type NodeResourceTopologyMatchArgs, got %T", args)
 	}
 
-	ctx := context.TODO()
 	client, err := topologyclientset.NewForConfig(handle.KubeConfig())
 	if err != nil {
 		klog.ErrorS(err, "Failed to create clientSet for NodeTopologyResource", "kubeConfig", handle.KubeConfig())
 		return nil, err
 	}
 
-	lister, err := initTopologyInformer(ctx, client)
+	topologyMatch := &TopologyMatch{
+		handle:                 handle,
+		topologyAwareResources: sets.NewString(cfg.TopologyAwareResources...),
+		stopCh:                 make(chan struct{}),
+	}
+
+	// the goroutines started below are stopped if the plugin fails to be created.
+	defer func() {
+		if err != nil {
+			topologyMatch.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-topologyMatch.stopCh
+		cancel()
+	}()
+
+	topologyMatch.lister, err = getTopologyLister(client)
 	if err != nil {
 		return nil, err
 	}
+	topologyMatch.PodTopologyCache = NewPodTopologyCache(ctx, 30*time.Minute)
 
-	topologyMatch := &TopologyMatch{
-		PodTopologyCache:       NewPodTopologyCache(ctx, 30*time.Minute),
-		handle:                 handle,
-		lister:                 lister,
-		topologyAwareResources: sets.NewString(cfg.TopologyAwareResources...),
+	if err := topologyMatch.initExemptions(cfg, handle); err != nil {
+		return nil, err
 	}
 
 	return topologyMatch, nil
 }
 
+var (
+	topologyListerLock sync.Mutex
+	// topologyLister is shared by the plugins of all profiles, whose informer runs for the
+	// whole process like the informers of scheduler.
+	topologyLister listerv1alpha1.NodeResourceTopologyLister
+)
+
+// getTopologyLister returns the lister of NodeResourceTopology objects, whose informer is
+// started and synced by the first caller.
+func getTopologyLister(client topologyclientset.Interface) (listerv1alpha1.NodeResourceTopologyLister, error) {
+	topologyListerLock.Lock()
+	defer topologyListerLock.Unlock()
+
+	if topologyLister == nil {
+		lister, err := initTopologyInformer(context.Background(), client)
+		if err != nil {
+			return nil, err
+		}
+		topologyLister = lister
+	}
+
+	return topologyLister, nil
+}
+
 func initTopologyInformer(
 	ctx context.Context,
 	client topologyclientset.Interface,
@@ -82,6 +123,13 @@ type TopologyMatch struct {
 	handle                 framework.Handle
 	lister                 listerv1alpha1.NodeResourceTopologyLister
 	topologyAwareResources sets.String
+	// exemptions stores *policy.ExemptionRules of the policy of Dynamic plugin.
+	exemptions atomic.Value
+	// cancelPolicy cancels the subscription to the policy shared by all profiles.
+	cancelPolicy func()
+	// stopCh stops the goroutines of the plugin once closed.
+	stopCh    chan struct{}
+	closeOnce sync.Once
 }
 
 // Name returns name of the plugin. It is used in logs, etc.
@@ -89,11 +137,25 @@ func (tm *TopologyMatch) Name() string {
 	return Name
 }
 
+// Close stops the goroutines of the plugin and cancels its subscription to the policy, which
+// is safe to call more than once.
+func (tm *TopologyMatch) Close() error {
+	tm.closeOnce.Do(func() {
+		close(tm.stopCh)
+		if tm.cancelPolicy != nil {
+			tm.cancelPolicy()
+		}
+	})
+	return nil
+}
+
 // stateData computed at PreFilter and used at Filter.
 type stateData struct {
 	sync.Mutex
 
 	aware *bool
+	// exempted is true if the pod matches the exemption rules of policy.
+	exempted bool
 	// If not empty, there are containers need to be bound.
 	targetContainerIndices  []int
 	targetContainerResource *framework.Resource
@@ -2,13 +2,18 @@ package utils
 
 import (
 	"os"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 )
 
 const (
-	TimeFormat       = "2006-01-02T15:04:05Z"
+	// TimeFormat is the legacy format of timestamps in node annotations, which is local time
+	// despite of the literal "Z".
+	TimeFormat = "2006-01-02T15:04:05Z"
+	// TimestampFormat is RFC3339 with numeric zone offset, which is distinguishable from TimeFormat.
+	TimestampFormat  = "2006-01-02T15:04:05-07:00"
 	DefaultTimeZone  = "Asia/Shanghai"
 	DefaultNamespace = "crane-system"
 )
@@ -23,6 +28,9 @@ func IsDaemonsetPod(pod *corev1.Pod) bool {
 	return false
 }
 
+// GetLocalTime returns the current time in TimeFormat.
+//
+// Deprecated: the result depends on the time zone, use FormatTimestamp instead.
 func GetLocalTime() string {
 	loc := GetLocation()
 	if loc == nil {
@@ -32,6 +40,11 @@ func GetLocalTime() string {
 	return time.Now().In(loc).Format(TimeFormat)
 }
 
+// FormatTimestamp formats t in UTC with TimestampFormat.
+func FormatTimestamp(t time.Time) string {
+	return t.UTC().Format(TimestampFormat)
+}
+
 func GetLocation() *time.Location {
 	zone := os.Getenv("TZ")
 
@@ -54,6 +67,19 @@ func GetSystemNamespace() string {
 	return ns
 }
 
+// GetResourceNameOfMetric returns the resource a usage metric refers to by its prefix,
+// e.g. cpu_usage_avg_5m refers to cpu and mem_usage_avg_5m refers to memory.
+func GetResourceNameOfMetric(metricName string) (corev1.ResourceName, bool) {
+	switch {
+	case strings.HasPrefix(metricName, "cpu"):
+		return corev1.ResourceCPU, true
+	case strings.HasPrefix(metricName, "mem"):
+		return corev1.ResourceMemory, true
+	}
+
+	return "", false
+}
+
 // NormalizaScore nornalize the score in range [min, max]
 func NormalizeScore(value, max, min int64) int64 {
 	if value < min {